@@ -25,6 +25,12 @@ func Analyze(result *redis.ScanResult, cfg AnalyzerConfig) *AnalysisResult {
 		summary.BySeverity[string(f.Severity)]++
 		summary.ByResourceType[f.ResourceType]++
 		summary.ByFindingID[string(f.ID)]++
+		if f.Owner != nil {
+			if summary.ByOwner == nil {
+				summary.ByOwner = make(map[string]int)
+			}
+			summary.ByOwner[f.Owner.Team]++
+		}
 	}
 
 	return &AnalysisResult{
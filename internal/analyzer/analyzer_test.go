@@ -92,3 +92,21 @@ func TestAnalyze_PreservesErrors(t *testing.T) {
 		t.Errorf("expected 1 error, got %d", len(analysis.Errors))
 	}
 }
+
+func TestAnalyze_ByOwner(t *testing.T) {
+	result := &redis.ScanResult{
+		Findings: []redis.Finding{
+			{ID: redis.FindingIdleKey, Severity: redis.SeverityMedium, ResourceType: "Key", Owner: &redis.Owner{Team: "@identity"}},
+			{ID: redis.FindingBigKey, Severity: redis.SeverityMedium, ResourceType: "Key", Owner: &redis.Owner{Team: "@identity"}},
+			{ID: redis.FindingHighFragmentation, Severity: redis.SeverityHigh, ResourceType: "Redis"},
+		},
+	}
+	analysis := Analyze(result, AnalyzerConfig{})
+
+	if analysis.Summary.ByOwner["@identity"] != 2 {
+		t.Errorf("expected 2 findings for @identity, got %d", analysis.Summary.ByOwner["@identity"])
+	}
+	if len(analysis.Summary.ByOwner) != 1 {
+		t.Errorf("expected 1 owner in summary, got %d", len(analysis.Summary.ByOwner))
+	}
+}
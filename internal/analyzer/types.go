@@ -11,6 +11,7 @@ type Summary struct {
 	BySeverity            map[string]int `json:"by_severity"`
 	ByResourceType        map[string]int `json:"by_resource_type"`
 	ByFindingID           map[string]int `json:"by_finding_id"`
+	ByOwner               map[string]int `json:"by_owner,omitempty"`
 }
 
 // AnalysisResult holds filtered findings and computed summary.
@@ -13,20 +13,50 @@ import (
 )
 
 var auditFlags struct {
-	format     string
-	outputFile string
-	sampleSize int
-	idleDays   int
-	bigKeySize int64
-	timeout    time.Duration
+	format            string
+	outputFile        string
+	sampleSize        int
+	idleDays          int
+	bigKeySize        int64
+	timeout           time.Duration
+	enable            []string
+	largeValueSize    int64
+	valueBudget       int64
+	ownersFile        string
+	idleConnThreshold int
+	latencyThreshold  time.Duration
+	sampleWindow      time.Duration
+	slowlogThreshold  time.Duration
+	slowlogEntries    int64
+	slowlogArgs       string
+	versionData       string
+	sampleInterval    time.Duration
+	stateFile         string
+	shardImbalance    float64
 }
 
 var auditCmd = &cobra.Command{
 	Use:   "audit",
 	Short: "Run full Redis audit",
 	Long: `Audit a Redis instance for waste and hygiene issues: memory fragmentation,
-idle keys, big keys, connection waste, eviction policy, persistence
-configuration, and slow commands.
+memory overhead, idle keys, big keys, connection waste, eviction policy, cache
+effectiveness, persistence configuration, slow commands, command statistics,
+latency monitor events, security configuration, ACL users, replication
+health, server restarts, version end of life and CVEs, RediSearch and
+TimeSeries module data, and cluster slot health and shard balance. Key-name
+bytes are measured per namespace, and key names are linted against naming
+rules when they are configured in .redisspectre.yaml.
+With --owners, every finding carries its owning team and unowned namespaces
+are reported. With --state-file, counters are compared with the previous run
+instead of the server's lifetime totals. With --sample-window, per-second
+rates such as connection churn and evictions are measured over a short window
+first.
+
+Optional auditors that read key values can be enabled with --enable:
+  duplicates   large string values stored under more than one key
+  compression  memory client-side compression would save per namespace
+  formats      serialization format mix per namespace (flags pickle/Java)
+  malloc_stats jemalloc size-class diagnosis of fragmentation
 
 Requires connectivity to the Redis instance.`,
 	RunE: runAudit,
@@ -39,6 +69,20 @@ func init() {
 	auditCmd.Flags().IntVar(&auditFlags.idleDays, "idle-days", 30, "Key inactivity threshold (days)")
 	auditCmd.Flags().Int64Var(&auditFlags.bigKeySize, "big-key-size", 10*1024*1024, "Big key threshold (bytes)")
 	auditCmd.Flags().DurationVar(&auditFlags.timeout, "timeout", 5*time.Minute, "Audit timeout")
+	auditCmd.Flags().StringSliceVar(&auditFlags.enable, "enable", nil, "Optional auditors to enable (comma-separated): duplicates, compression, formats, malloc_stats")
+	auditCmd.Flags().Int64Var(&auditFlags.largeValueSize, "large-value-size", 64*1024, "Minimum value size inspected by value auditors (bytes)")
+	auditCmd.Flags().StringVar(&auditFlags.ownersFile, "owners", "", "Key ownership file (CODEOWNERS-style prefix/glob to team and service)")
+	auditCmd.Flags().Int64Var(&auditFlags.valueBudget, "value-budget", 64*1024*1024, "Maximum value bytes each value auditor may read")
+	auditCmd.Flags().StringVar(&auditFlags.versionData, "version-data", "", "Version end-of-life and CVE table replacing the built-in one (JSON)")
+	auditCmd.Flags().StringVar(&auditFlags.stateFile, "state-file", "", "Counters state file; findings report counter deltas since the previous run")
+	auditCmd.Flags().DurationVar(&auditFlags.latencyThreshold, "latency-threshold", 100*time.Millisecond, "Latency monitor event duration reported as a spike")
+	auditCmd.Flags().DurationVar(&auditFlags.sampleWindow, "sample-window", 0, "Measure counter rates over this window before auditing (e.g. 30s)")
+	auditCmd.Flags().DurationVar(&auditFlags.sampleInterval, "sample-interval", 0, "Snapshot interval within --sample-window (default: start and end only)")
+	auditCmd.Flags().DurationVar(&auditFlags.slowlogThreshold, "slowlog-threshold", 10*time.Millisecond, "Slowlog entry duration reported as a slow command")
+	auditCmd.Flags().Int64Var(&auditFlags.slowlogEntries, "slowlog-entries", 128, "Number of slowlog entries to read")
+	auditCmd.Flags().StringVar(&auditFlags.slowlogArgs, "slowlog-args", "keys", "Slowlog arguments in reports: keys, lengths, or full (credentials are always masked)")
+	auditCmd.Flags().Float64Var(&auditFlags.shardImbalance, "shard-imbalance-ratio", 1.5, "Largest cluster shard over the shard average (keys, memory, or ops) reported as imbalanced")
+	auditCmd.Flags().IntVar(&auditFlags.idleConnThreshold, "idle-conn-threshold", 100, "Idle connections per source IP, client name, or library before reporting")
 
 	rootCmd.AddCommand(auditCmd)
 }
@@ -53,6 +97,10 @@ func runAudit(cmd *cobra.Command, _ []string) error {
 
 	applyConfigDefaults()
 
+	if auditFlags.timeout > 0 && auditFlags.sampleWindow >= auditFlags.timeout {
+		return fmt.Errorf("--sample-window %s must be shorter than --timeout %s", auditFlags.sampleWindow, auditFlags.timeout)
+	}
+
 	resolvedAddr := resolveAddr()
 	resolvedPassword := resolvePassword()
 
@@ -66,21 +114,94 @@ func runAudit(cmd *cobra.Command, _ []string) error {
 		return enhanceError("connect to redis", err)
 	}
 
+	profile, err := redis.DetectProfile(ctx, client)
+	if err != nil {
+		slog.Warn("Server detection failed; assuming all capabilities", "error", err)
+	} else {
+		slog.Info("Detected server", "server", profile.Server.String(), "managed", profile.Managed)
+	}
+
+	auditors, err := redis.SelectAuditors(auditFlags.enable)
+	if err != nil {
+		return err
+	}
+
+	namingRules, err := compileNamingRules(cfg.Naming.Rules)
+	if err != nil {
+		return err
+	}
+
+	owners, err := loadOwners(auditFlags.ownersFile)
+	if err != nil {
+		return err
+	}
+
+	latencyEvents, err := parseLatencyEvents(cfg.Latency.Events)
+	if err != nil {
+		return err
+	}
+
+	slowlogArgs, err := redis.ParseArgRedaction(auditFlags.slowlogArgs)
+	if err != nil {
+		return err
+	}
+
+	versionData, err := loadVersionData(auditFlags.versionData)
+	if err != nil {
+		return err
+	}
+
+	state, err := loadState(auditFlags.stateFile)
+	if err != nil {
+		return err
+	}
+	stateKey := redis.StateKey(resolvedAddr, db)
+	var previous *redis.Snapshot
+	if snap, ok := state[stateKey]; ok {
+		previous = &snap
+	}
+
 	auditCfg := redis.AuditConfig{
-		Addr:       resolvedAddr,
-		DB:         db,
-		SampleSize: auditFlags.sampleSize,
-		IdleDays:   auditFlags.idleDays,
-		BigKeySize: auditFlags.bigKeySize,
+		Addr:                   resolvedAddr,
+		DB:                     db,
+		SampleSize:             auditFlags.sampleSize,
+		IdleDays:               auditFlags.idleDays,
+		BigKeySize:             auditFlags.bigKeySize,
+		LargeValueSize:         auditFlags.largeValueSize,
+		ValueBudget:            auditFlags.valueBudget,
+		NamingRules:            namingRules,
+		MaxKeyLength:           cfg.Naming.MaxKeyLength,
+		Owners:                 owners,
+		IdleConnThreshold:      auditFlags.idleConnThreshold,
+		LatencyThreshold:       auditFlags.latencyThreshold,
+		LatencyEventThresholds: latencyEvents,
+		PreviousSnapshot:       previous,
+		Profile:                profile,
+		SlowLogThreshold:       auditFlags.slowlogThreshold,
+		SlowLogEntries:         auditFlags.slowlogEntries,
+		SlowLogArgs:            slowlogArgs,
+		VersionData:            versionData,
+		SampleWindow:           auditFlags.sampleWindow,
+		SampleInterval:         auditFlags.sampleInterval,
+		DialShard:              shardDialer(resolvedPassword),
+		ShardImbalanceRatio:    auditFlags.shardImbalance,
 	}
 
 	slog.Info("Starting audit", "addr", resolvedAddr, "db", db, "sample-size", auditFlags.sampleSize)
 
-	multi := redis.NewMultiAuditor(redis.AllAuditors(), 4)
+	multi := redis.NewMultiAuditor(auditors, 4)
 	result, err := multi.AuditAll(ctx, client, auditCfg)
 	if err != nil {
 		return enhanceError("audit redis", err)
 	}
+	redis.AssignOwners(result.Findings, owners)
+
+	if auditFlags.stateFile != "" && result.Snapshot != nil {
+		state[stateKey] = *result.Snapshot
+		if err := saveState(auditFlags.stateFile, state); err != nil {
+			return err
+		}
+	}
 
 	analysis := analyzer.Analyze(result, analyzer.AnalyzerConfig{})
 
@@ -98,9 +219,11 @@ func runAudit(cmd *cobra.Command, _ []string) error {
 			SampleSize: auditFlags.sampleSize,
 			IdleDays:   auditFlags.idleDays,
 		},
-		Findings: analysis.Findings,
-		Summary:  analysis.Summary,
-		Errors:   analysis.Errors,
+		Findings:  analysis.Findings,
+		Summary:   analysis.Summary,
+		Errors:    analysis.Errors,
+		Skipped:   result.Skipped,
+		Inventory: result.Inventory,
 	}
 
 	reporter, err := selectReporter(auditFlags.format, auditFlags.outputFile)
@@ -132,4 +255,46 @@ func applyConfigDefaults() {
 	if auditFlags.bigKeySize == 10*1024*1024 && cfg.BigKeySize > 0 {
 		auditFlags.bigKeySize = cfg.BigKeySize
 	}
+	if auditFlags.stateFile == "" && cfg.StateFile != "" {
+		auditFlags.stateFile = cfg.StateFile
+	}
+	if auditFlags.versionData == "" && cfg.VersionData != "" {
+		auditFlags.versionData = cfg.VersionData
+	}
+	if auditFlags.ownersFile == "" && cfg.OwnersFile != "" {
+		auditFlags.ownersFile = cfg.OwnersFile
+	}
+	if len(auditFlags.enable) == 0 && len(cfg.Enable) > 0 {
+		auditFlags.enable = cfg.Enable
+	}
+	if auditFlags.largeValueSize == 64*1024 && cfg.LargeValueSize > 0 {
+		auditFlags.largeValueSize = cfg.LargeValueSize
+	}
+	if auditFlags.valueBudget == 64*1024*1024 && cfg.ValueBudget > 0 {
+		auditFlags.valueBudget = cfg.ValueBudget
+	}
+	if auditFlags.idleConnThreshold == 100 && cfg.IdleConnThreshold > 0 {
+		auditFlags.idleConnThreshold = cfg.IdleConnThreshold
+	}
+	if auditFlags.latencyThreshold == 100*time.Millisecond && cfg.Latency.ThresholdDuration() > 0 {
+		auditFlags.latencyThreshold = cfg.Latency.ThresholdDuration()
+	}
+	if auditFlags.slowlogThreshold == 10*time.Millisecond && cfg.Slowlog.ThresholdDuration() > 0 {
+		auditFlags.slowlogThreshold = cfg.Slowlog.ThresholdDuration()
+	}
+	if auditFlags.slowlogEntries == 128 && cfg.Slowlog.Entries > 0 {
+		auditFlags.slowlogEntries = cfg.Slowlog.Entries
+	}
+	if auditFlags.slowlogArgs == "keys" && cfg.Slowlog.Args != "" {
+		auditFlags.slowlogArgs = cfg.Slowlog.Args
+	}
+	if auditFlags.sampleWindow == 0 && cfg.SampleWindowDuration() > 0 {
+		auditFlags.sampleWindow = cfg.SampleWindowDuration()
+	}
+	if auditFlags.sampleInterval == 0 && cfg.SampleIntervalDuration() > 0 {
+		auditFlags.sampleInterval = cfg.SampleIntervalDuration()
+	}
+	if auditFlags.shardImbalance == 1.5 && cfg.ShardImbalance > 0 {
+		auditFlags.shardImbalance = cfg.ShardImbalance
+	}
 }
@@ -1,11 +1,19 @@
 package commands
 
 import (
+	"context"
 	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/ppiankov/redisspectre/internal/config"
+	"github.com/ppiankov/redisspectre/internal/redis"
 	"github.com/ppiankov/redisspectre/internal/report"
 )
 
@@ -20,7 +28,7 @@ func enhanceError(action string, err error) error {
 	case strings.Contains(msg, "NOAUTH") || strings.Contains(msg, "ERR AUTH"):
 		hint = "Authentication failed. Check --password or REDIS_PASSWORD environment variable"
 	case strings.Contains(msg, "NOPERM") || strings.Contains(msg, "no permissions"):
-		hint = "Insufficient permissions. redisspectre needs INFO, SCAN, OBJECT, MEMORY, SLOWLOG, and CONFIG GET access"
+		hint = "Insufficient permissions. redisspectre needs INFO, SCAN, OBJECT, MEMORY, SLOWLOG, LATENCY, CONFIG GET, COMMAND, ACL LIST/LOG, and CLIENT LIST access"
 	case strings.Contains(msg, "timeout") || strings.Contains(msg, "deadline exceeded"):
 		hint = "Operation timed out. Try increasing --timeout"
 	case strings.Contains(msg, "EOF") || strings.Contains(msg, "broken pipe"):
@@ -64,3 +72,120 @@ func selectReporter(format, outputFile string) (report.Reporter, error) {
 		return nil, fmt.Errorf("unsupported format: %s (use text, json, sarif, or spectrehub)", format)
 	}
 }
+
+// compileNamingRules turns configured naming rules into matchers for the naming auditor.
+func compileNamingRules(rules []config.NamingRule) ([]redis.NamingRule, error) {
+	compiled := make([]redis.NamingRule, 0, len(rules))
+	for i, r := range rules {
+		name := r.Name
+		if name == "" {
+			name = fmt.Sprintf("rule-%d", i+1)
+		}
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("naming rule %q: invalid pattern: %w", name, err)
+		}
+		compiled = append(compiled, redis.NamingRule{Name: name, Pattern: re})
+	}
+	return compiled, nil
+}
+
+// parseLatencyEvents parses per-event latency thresholds such as "fork: 500ms".
+func parseLatencyEvents(events map[string]string) (map[string]time.Duration, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	parsed := make(map[string]time.Duration, len(events))
+	for event, value := range events {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("latency threshold for %q: %w", event, err)
+		}
+		parsed[event] = d
+	}
+	return parsed, nil
+}
+
+// loadState reads the counters state file; a missing file is an empty state.
+func loadState(path string) (redis.State, error) {
+	state := make(redis.State)
+	if path == "" {
+		return state, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parse state file %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveState writes the counters state file.
+func saveState(path string, state redis.State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode state: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("write state file: %w", err)
+	}
+	return nil
+}
+
+// loadOwners reads the key ownership file, if one is configured.
+func loadOwners(path string) (*redis.Owners, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open owners file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	owners, err := redis.ParseOwners(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse owners file %s: %w", path, err)
+	}
+	return owners, nil
+}
+
+// shardDialer connects to other nodes of a cluster with the audit's
+// password. Cluster nodes only serve database 0.
+func shardDialer(password string) func(ctx context.Context, addr string) (redis.RedisClient, error) {
+	return func(ctx context.Context, addr string) (redis.RedisClient, error) {
+		client, err := redis.NewClient(addr, password, 0)
+		if err != nil {
+			return nil, err
+		}
+		if err := client.Ping(ctx); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+		return client, nil
+	}
+}
+
+// loadVersionData reads a version table replacing the built-in one; an empty
+// path keeps the built-in table.
+func loadVersionData(path string) (*redis.VersionData, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open version data: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := redis.ParseVersionData(f)
+	if err != nil {
+		return nil, fmt.Errorf("parse version data %s: %w", path, err)
+	}
+	return data, nil
+}
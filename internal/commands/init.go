@@ -72,6 +72,60 @@ idle_days: 30
 # Big key threshold (bytes, default 10MB)
 big_key_size: 10485760
 
+# Optional auditors that read key values (off by default)
+# enable:
+#   - duplicates
+#   - compression
+#   - formats
+#   - malloc_stats
+
+# Minimum value size inspected by value auditors (bytes, default 64KB)
+large_value_size: 65536
+
+# Maximum value bytes each value auditor may read (default 64MB)
+value_budget: 67108864
+
+# Idle connections (idle 5m+) per source IP, client name, or library before reporting
+idle_conn_threshold: 100
+
+# Latency monitor events at or above the threshold are reported (default 100ms)
+# latency:
+#   threshold: 100ms
+#   events:
+#     fork: 500ms
+
+# Slowlog entries at or above the threshold are grouped by command and key pattern
+# slowlog:
+#   threshold: 10ms
+#   entries: 128
+#   args: keys   # keys (default), lengths, or full; credentials are always masked
+
+# Version end-of-life and CVE table replacing the built-in one (same JSON format)
+# version_data: redis-versions.json
+
+# Largest cluster shard over the shard average (keys, memory, or ops) reported as imbalanced
+# shard_imbalance_ratio: 1.5
+
+# Counters state file: counter-based findings report deltas since the previous run
+# state_file: .redisspectre-state.json
+
+# Measure ops, evictions, and connection rates over a window before auditing
+# (counts toward timeout); sample_interval adds snapshots within the window
+# sample_window: 30s
+# sample_interval: 5s
+
+# Key naming rules: every sampled key must match each pattern
+# naming:
+#   max_key_length: 128
+#   rules:
+#     - name: service-entity-id
+#       pattern: '^[a-z0-9-]+:[a-z0-9-]+:.+$'
+#     - name: lowercase
+#       pattern: '^[^A-Z]*$'
+
+# Key ownership file (CODEOWNERS-style: <prefix-or-glob> <team> [service])
+# owners_file: .redisspectre-owners
+
 # Output format: text, json, sarif, spectrehub
 format: text
 
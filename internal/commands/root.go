@@ -25,10 +25,12 @@ var rootCmd = &cobra.Command{
 	Short: "redisspectre — Redis waste and hygiene auditor",
 	Long: `redisspectre audits Redis instances for waste and hygiene issues: memory
 fragmentation, idle keys, big keys, connection waste, eviction policy,
-persistence configuration, and slow commands.
+persistence configuration, slow commands, and security configuration.
 
 Read-only: never modifies data. Uses INFO, SCAN, OBJECT IDLETIME,
-MEMORY USAGE, SLOWLOG GET, and CONFIG GET.`,
+MEMORY USAGE, SLOWLOG GET, CONFIG GET, COMMAND, ACL LIST, ACL LOG, and
+CLIENT LIST. Optional auditors also
+read values with TYPE, STRLEN, GETRANGE, and HSCAN.`,
 	PersistentPreRun: func(_ *cobra.Command, _ []string) {
 		logging.Init(verbose)
 		loaded, err := config.Load(".")
@@ -11,14 +11,69 @@ import (
 
 // Config holds redisspectre configuration loaded from .redisspectre.yaml.
 type Config struct {
-	Addr       string `yaml:"addr"`
-	Password   string `yaml:"password"`
-	DB         int    `yaml:"db"`
-	SampleSize int    `yaml:"sample_size"`
-	IdleDays   int    `yaml:"idle_days"`
-	BigKeySize int64  `yaml:"big_key_size"`
-	Format     string `yaml:"format"`
-	Timeout    string `yaml:"timeout"`
+	Addr              string   `yaml:"addr"`
+	Password          string   `yaml:"password"`
+	DB                int      `yaml:"db"`
+	SampleSize        int      `yaml:"sample_size"`
+	IdleDays          int      `yaml:"idle_days"`
+	BigKeySize        int64    `yaml:"big_key_size"`
+	Format            string   `yaml:"format"`
+	Timeout           string   `yaml:"timeout"`
+	Enable            []string `yaml:"enable"`
+	LargeValueSize    int64    `yaml:"large_value_size"`
+	ValueBudget       int64    `yaml:"value_budget"`
+	Naming            Naming   `yaml:"naming"`
+	OwnersFile        string   `yaml:"owners_file"`
+	IdleConnThreshold int      `yaml:"idle_conn_threshold"`
+	Latency           Latency  `yaml:"latency"`
+	Slowlog           Slowlog  `yaml:"slowlog"`
+	StateFile         string   `yaml:"state_file"`
+	SampleWindow      string   `yaml:"sample_window"`
+	SampleInterval    string   `yaml:"sample_interval"`
+	VersionData       string   `yaml:"version_data"`
+	ShardImbalance    float64  `yaml:"shard_imbalance_ratio"`
+}
+
+// Naming holds key naming rules enforced by the naming auditor.
+type Naming struct {
+	MaxKeyLength int          `yaml:"max_key_length"`
+	Rules        []NamingRule `yaml:"rules"`
+}
+
+// Latency holds latency monitor thresholds: a default and per-event
+// overrides such as "fork: 500ms".
+type Latency struct {
+	Threshold string            `yaml:"threshold"`
+	Events    map[string]string `yaml:"events"`
+}
+
+// ThresholdDuration parses the default latency threshold as a duration.
+func (l Latency) ThresholdDuration() time.Duration {
+	if l.Threshold == "" {
+		return 0
+	}
+	d, _ := time.ParseDuration(l.Threshold)
+	return d
+}
+
+// Slowlog holds the slowlog auditor's duration threshold, the number of
+// entries it reads, and how command arguments are redacted.
+type Slowlog struct {
+	Threshold string `yaml:"threshold"`
+	Entries   int64  `yaml:"entries"`
+	Args      string `yaml:"args"`
+}
+
+// ThresholdDuration parses the slowlog threshold as a duration.
+func (s Slowlog) ThresholdDuration() time.Duration {
+	d, _ := time.ParseDuration(s.Threshold)
+	return d
+}
+
+// NamingRule is a named regular expression that key names must match.
+type NamingRule struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"`
 }
 
 // TimeoutDuration parses the timeout string as a duration.
@@ -30,6 +85,18 @@ func (c Config) TimeoutDuration() time.Duration {
 	return d
 }
 
+// SampleWindowDuration parses the sample window as a duration.
+func (c Config) SampleWindowDuration() time.Duration {
+	d, _ := time.ParseDuration(c.SampleWindow)
+	return d
+}
+
+// SampleIntervalDuration parses the sample interval as a duration.
+func (c Config) SampleIntervalDuration() time.Duration {
+	d, _ := time.ParseDuration(c.SampleInterval)
+	return d
+}
+
 // Load searches for .redisspectre.yaml or .redisspectre.yml in the given directory
 // and returns the parsed config. Returns an empty Config if no file is found.
 func Load(dir string) (Config, error) {
@@ -16,6 +16,9 @@ idle_days: 60
 big_key_size: 5242880
 format: json
 timeout: 10m
+enable: [duplicates]
+large_value_size: 4096
+value_budget: 1048576
 `
 	if err := os.WriteFile(filepath.Join(dir, ".redisspectre.yaml"), []byte(content), 0o644); err != nil {
 		t.Fatal(err)
@@ -46,6 +49,15 @@ timeout: 10m
 	if cfg.Timeout != "10m" {
 		t.Errorf("expected timeout '10m', got %q", cfg.Timeout)
 	}
+	if len(cfg.Enable) != 1 || cfg.Enable[0] != "duplicates" {
+		t.Errorf("expected enable [duplicates], got %v", cfg.Enable)
+	}
+	if cfg.LargeValueSize != 4096 {
+		t.Errorf("expected large_value_size 4096, got %d", cfg.LargeValueSize)
+	}
+	if cfg.ValueBudget != 1048576 {
+		t.Errorf("expected value_budget 1048576, got %d", cfg.ValueBudget)
+	}
 }
 
 func TestLoad_NotFound(t *testing.T) {
@@ -75,6 +87,30 @@ func TestLoad_YMLExtension(t *testing.T) {
 	}
 }
 
+func TestLoad_NamingRules(t *testing.T) {
+	dir := t.TempDir()
+	content := `naming:
+  max_key_length: 64
+  rules:
+    - name: lowercase
+      pattern: '^[^A-Z]*$'
+`
+	if err := os.WriteFile(filepath.Join(dir, ".redisspectre.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Naming.MaxKeyLength != 64 {
+		t.Errorf("expected max_key_length 64, got %d", cfg.Naming.MaxKeyLength)
+	}
+	if len(cfg.Naming.Rules) != 1 || cfg.Naming.Rules[0].Name != "lowercase" || cfg.Naming.Rules[0].Pattern != "^[^A-Z]*$" {
+		t.Errorf("unexpected naming rules: %+v", cfg.Naming.Rules)
+	}
+}
+
 func TestLoad_InvalidYAML(t *testing.T) {
 	dir := t.TempDir()
 	content := `{invalid yaml[[`
@@ -99,3 +135,35 @@ func TestTimeoutDuration(t *testing.T) {
 		t.Errorf("expected 0 for empty timeout, got %v", cfg2.TimeoutDuration())
 	}
 }
+
+func TestLatencyThresholdDuration(t *testing.T) {
+	l := Latency{Threshold: "250ms"}
+	if l.ThresholdDuration() != 250*time.Millisecond {
+		t.Errorf("expected 250ms, got %v", l.ThresholdDuration())
+	}
+	if (Latency{}).ThresholdDuration() != 0 {
+		t.Error("expected 0 for empty threshold")
+	}
+}
+
+func TestSampleWindowDuration(t *testing.T) {
+	cfg := Config{SampleWindow: "30s", SampleInterval: "5s"}
+	if cfg.SampleWindowDuration() != 30*time.Second {
+		t.Errorf("expected 30s, got %v", cfg.SampleWindowDuration())
+	}
+	if cfg.SampleIntervalDuration() != 5*time.Second {
+		t.Errorf("expected 5s, got %v", cfg.SampleIntervalDuration())
+	}
+	if (Config{}).SampleWindowDuration() != 0 {
+		t.Error("expected 0 for empty sample window")
+	}
+}
+
+func TestSlowlogThresholdDuration(t *testing.T) {
+	if d := (Slowlog{Threshold: "25ms"}).ThresholdDuration(); d != 25*time.Millisecond {
+		t.Errorf("expected 25ms, got %v", d)
+	}
+	if d := (Slowlog{}).ThresholdDuration(); d != 0 {
+		t.Errorf("expected 0 for empty threshold, got %v", d)
+	}
+}
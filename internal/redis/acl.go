@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ACLUser is a user parsed from an ACL LIST line.
+type ACLUser struct {
+	Name            string   `json:"name"`
+	Enabled         bool     `json:"enabled"`
+	NoPass          bool     `json:"nopass"`
+	Passwords       int      `json:"passwords"`
+	KeyPatterns     []string `json:"key_patterns,omitempty"`
+	ChannelPatterns []string `json:"channel_patterns,omitempty"`
+	CommandRules    []string `json:"command_rules,omitempty"`
+	Selectors       []string `json:"selectors,omitempty"`
+}
+
+// ParseACLUser parses one line of ACL LIST output, e.g.
+// "user alice on #<sha256> ~cache:* &* -@all +get".
+func ParseACLUser(line string) (ACLUser, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "user" {
+		return ACLUser{}, fmt.Errorf("invalid ACL rule: %q", line)
+	}
+
+	u := ACLUser{Name: fields[1]}
+	for i := 2; i < len(fields); i++ {
+		tok := fields[i]
+		switch {
+		case tok == "on":
+			u.Enabled = true
+		case tok == "off":
+			u.Enabled = false
+		case tok == "nopass":
+			u.NoPass = true
+			u.Passwords = 0
+		case tok == "resetpass":
+			u.NoPass = false
+			u.Passwords = 0
+		case strings.HasPrefix(tok, "#"), strings.HasPrefix(tok, ">"):
+			u.Passwords++
+		case tok == "allkeys":
+			u.KeyPatterns = append(u.KeyPatterns, "~*")
+		case strings.HasPrefix(tok, "~"), strings.HasPrefix(tok, "%"):
+			u.KeyPatterns = append(u.KeyPatterns, tok)
+		case tok == "allchannels":
+			u.ChannelPatterns = append(u.ChannelPatterns, "&*")
+		case strings.HasPrefix(tok, "&"):
+			u.ChannelPatterns = append(u.ChannelPatterns, tok)
+		case tok == "resetkeys":
+			u.KeyPatterns = nil
+		case tok == "resetchannels":
+			u.ChannelPatterns = nil
+		case tok == "allcommands", tok == "nocommands",
+			strings.HasPrefix(tok, "+"), strings.HasPrefix(tok, "-"):
+			u.CommandRules = append(u.CommandRules, tok)
+		case strings.HasPrefix(tok, "("):
+			// Selectors span several tokens: "(~key* +get)".
+			sel := tok
+			for !strings.HasSuffix(sel, ")") && i+1 < len(fields) {
+				i++
+				sel += " " + fields[i]
+			}
+			u.Selectors = append(u.Selectors, sel)
+		}
+	}
+	return u, nil
+}
+
+// AllKeys reports whether the user can access every key.
+func (u ACLUser) AllKeys() bool {
+	for _, p := range u.KeyPatterns {
+		if p == "~*" || p == "%RW~*" {
+			return true
+		}
+	}
+	return false
+}
+
+// AllCommands reports whether the user's root rules end up granting every
+// command with nothing subtracted afterwards.
+func (u ACLUser) AllCommands() bool {
+	all := false
+	for _, rule := range u.CommandRules {
+		switch {
+		case rule == "allcommands" || rule == "+@all":
+			all = true
+		case strings.HasPrefix(rule, "-") || rule == "nocommands":
+			all = false
+		}
+	}
+	return all
+}
+
+// Allows reports whether the user's root command rules permit command,
+// given the command's ACL categories as reported by COMMAND (e.g. "@admin").
+// Rules are applied in order, as Redis does.
+func (u ACLUser) Allows(command string, categories []string) bool {
+	command = strings.ToLower(command)
+	allowed := false
+	for _, rule := range u.CommandRules {
+		switch rule {
+		case "allcommands", "+@all":
+			allowed = true
+			continue
+		case "nocommands", "-@all":
+			allowed = false
+			continue
+		}
+
+		grant := rule[0] == '+'
+		target := strings.ToLower(rule[1:])
+		switch {
+		case strings.HasPrefix(target, "@"):
+			if inCategories(categories, target[1:]) {
+				allowed = grant
+			}
+		case strings.Contains(target, "|"):
+			// Subcommand rules only grant part of the command.
+		case target == command:
+			allowed = grant
+		}
+	}
+	return allowed
+}
+
+func inCategories(categories []string, cat string) bool {
+	for _, c := range categories {
+		if strings.EqualFold(strings.TrimPrefix(c, "@"), cat) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package redis
+
+import "testing"
+
+func TestParseACLUser(t *testing.T) {
+	u, err := ParseACLUser("user alice on #2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b #abc ~cache:* &* resetchannels &news -@all +get +@read (~tmp:* +set)")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.Name != "alice" || !u.Enabled || u.NoPass {
+		t.Errorf("unexpected user: %+v", u)
+	}
+	if u.Passwords != 2 {
+		t.Errorf("expected 2 passwords, got %d", u.Passwords)
+	}
+	if len(u.KeyPatterns) != 1 || u.KeyPatterns[0] != "~cache:*" {
+		t.Errorf("unexpected key patterns: %v", u.KeyPatterns)
+	}
+	if len(u.ChannelPatterns) != 1 || u.ChannelPatterns[0] != "&news" {
+		t.Errorf("unexpected channel patterns: %v", u.ChannelPatterns)
+	}
+	if len(u.CommandRules) != 3 {
+		t.Errorf("expected 3 command rules, got %v", u.CommandRules)
+	}
+	if len(u.Selectors) != 1 || u.Selectors[0] != "(~tmp:* +set)" {
+		t.Errorf("unexpected selectors: %v", u.Selectors)
+	}
+}
+
+func TestParseACLUser_Invalid(t *testing.T) {
+	if _, err := ParseACLUser("not an acl line"); err == nil {
+		t.Error("expected error for invalid line")
+	}
+}
+
+func TestACLUserAllows(t *testing.T) {
+	flushall := []string{"@keyspace", "@write", "@slow", "@dangerous"}
+	get := []string{"@read", "@string", "@fast"}
+
+	tests := []struct {
+		rule    string
+		command string
+		cats    []string
+		want    bool
+	}{
+		{"user default on nopass ~* &* +@all", "flushall", flushall, true},
+		{"user default on nopass ~* &* +@all -@dangerous", "flushall", flushall, false},
+		{"user default on nopass ~* &* +@all -@dangerous", "get", get, true},
+		{"user default on nopass ~* &* +@all -flushall", "flushall", flushall, false},
+		{"user app on >x ~* -@all +@read", "flushall", flushall, false},
+		{"user app on >x ~* -@all +@dangerous -flushall +flushall", "flushall", flushall, true},
+		{"user app on >x ~* allcommands", "flushall", flushall, true},
+		{"user app on >x ~* nocommands +config|get", "config", nil, false},
+	}
+	for _, tt := range tests {
+		u, err := ParseACLUser(tt.rule)
+		if err != nil {
+			t.Fatalf("parse %q: %v", tt.rule, err)
+		}
+		if got := u.Allows(tt.command, tt.cats); got != tt.want {
+			t.Errorf("%q Allows(%s) = %v, want %v", tt.rule, tt.command, got, tt.want)
+		}
+	}
+}
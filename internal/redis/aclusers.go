@@ -0,0 +1,226 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dangerousCategories grant commands that can wipe data, change server
+// configuration, or run arbitrary code.
+var dangerousCategories = []string{"dangerous", "admin"}
+
+// aclGrantExamples caps the example commands listed per category.
+const aclGrantExamples = 10
+
+// aclGrant summarizes the commands of one dangerous category a user may run.
+type aclGrant struct {
+	Category string   `json:"category"`
+	Commands int      `json:"commands,omitempty"`
+	Examples []string `json:"examples,omitempty"`
+}
+
+func (g aclGrant) String() string {
+	if g.Commands == 0 {
+		return "@" + g.Category
+	}
+	examples := g.Examples
+	more := ""
+	if len(examples) > 3 {
+		examples, more = examples[:3], ", ..."
+	}
+	return fmt.Sprintf("@%s (%d commands: %s%s)", g.Category, g.Commands, strings.Join(examples, ", "), more)
+}
+
+// ACLUserScanner reviews ACL users on Redis 6+: overprivileged users, users
+// without passwords, users with no connection during the audit, forgotten
+// password rotations, and access to dangerous command categories.
+type ACLUserScanner struct{}
+
+func (s *ACLUserScanner) Name() string { return "acl_users" }
+
+// Applicable requires ACL LIST (Redis 6.0+).
+func (s *ACLUserScanner) Applicable(p *Profile) (string, bool) { return p.require(CapACL) }
+
+func (s *ACLUserScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	lines, err := client.ACLList(ctx)
+	if err != nil {
+		if isUnknownCommand(err) {
+			// Servers before 6.0 have no ACLs.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("acl list: %w", err)
+	}
+
+	users := make([]ACLUser, 0, len(lines))
+	for _, line := range lines {
+		u, err := ParseACLUser(line)
+		if err != nil {
+			continue
+		}
+		users = append(users, u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	cfg.inventory.set("acl_users", users)
+
+	// Redis keeps no history of successful authentications, so the only
+	// usage signal is who is connected now. Without CLIENT LIST there is
+	// none, and the check is skipped rather than reporting every user.
+	var connected map[string]int
+	if raw, err := client.ClientList(ctx); err == nil {
+		connected = make(map[string]int)
+		for _, c := range ParseClientList(raw) {
+			connected[c.User]++
+		}
+	} else {
+		cfg.skipped.add(SkippedCheck{
+			Auditor: s.Name(),
+			Check:   string(FindingACLUserNotConnected),
+			Reason:  fmt.Sprintf("CLIENT LIST failed: %v", err),
+		})
+	}
+
+	authFailures := make(map[string]int64)
+	if entries, err := client.ACLLog(ctx, 128); err == nil {
+		for _, e := range entries {
+			if e.Reason == "auth" {
+				authFailures[e.Username] += e.Count
+			}
+		}
+	}
+
+	// Command categories let rules like -@dangerous be evaluated exactly.
+	commands, _ := client.Commands(ctx)
+
+	var findings []Finding
+	for _, u := range users {
+		if !u.Enabled {
+			continue
+		}
+		connections := -1
+		if connected != nil {
+			connections = connected[u.Name]
+		}
+		findings = append(findings, s.userFindings(u, commands, connections, authFailures[u.Name])...)
+	}
+	return findings, nil
+}
+
+// userFindings reviews one user; connections is -1 when CLIENT LIST could
+// not be read.
+func (s *ACLUserScanner) userFindings(u ACLUser, commands map[string][]string, connections int, authFailures int64) []Finding {
+	var findings []Finding
+
+	finding := func(id FindingID, severity Severity, message string, extra map[string]any) {
+		meta := map[string]any{
+			"user": u.Name,
+			"acl":  u,
+		}
+		for k, v := range extra {
+			meta[k] = v
+		}
+		findings = append(findings, Finding{
+			ID:           id,
+			Severity:     severity,
+			ResourceType: "ACLUser",
+			ResourceID:   u.Name,
+			Message:      message,
+			Metadata:     meta,
+		})
+	}
+
+	if u.AllCommands() && u.AllKeys() {
+		finding(FindingACLOverprivileged, SeverityHigh,
+			fmt.Sprintf("ACL user %q can run every command on every key", u.Name),
+			map[string]any{"recommendation": "grant only the command categories and key patterns the user needs"})
+	} else if granted := dangerousGrants(u, commands); len(granted) > 0 {
+		parts := make([]string, len(granted))
+		for i, g := range granted {
+			parts[i] = g.String()
+		}
+		finding(FindingACLDangerousAccess, SeverityMedium,
+			fmt.Sprintf("ACL user %q has access to dangerous commands: %s", u.Name, strings.Join(parts, ", ")),
+			map[string]any{"granted": granted})
+	}
+
+	// The default user without a password is reported by the security auditor.
+	if u.NoPass && u.Name != "default" {
+		finding(FindingACLNoPassword, SeverityHigh,
+			fmt.Sprintf("ACL user %q is enabled with nopass", u.Name),
+			map[string]any{"recommendation": fmt.Sprintf("ACL SETUSER %s resetpass >new-password", u.Name)})
+	}
+
+	if u.Passwords > 1 {
+		finding(FindingACLMultiplePasswords, SeverityLow,
+			fmt.Sprintf("ACL user %q has %d passwords; old ones may be left over from rotations", u.Name, u.Passwords),
+			map[string]any{"passwords": u.Passwords})
+	}
+
+	// A user that only connects from cron or batch jobs looks the same as a
+	// forgotten one, so this is a lead to verify, not proof of disuse.
+	if connections == 0 && u.Name != "default" {
+		msg := fmt.Sprintf("ACL user %q is enabled but was not connected during the audit", u.Name)
+		if authFailures > 0 {
+			msg = fmt.Sprintf("ACL user %q is enabled, was not connected during the audit, and ACL LOG shows %d failed authentications", u.Name, authFailures)
+		}
+		finding(FindingACLUserNotConnected, SeverityLow, msg, map[string]any{
+			"auth_failures":  authFailures,
+			"confidence":     "low",
+			"recommendation": "Redis does not record successful authentications; confirm with the user's owners or across several audits before disabling it, since scheduled jobs connect only while they run",
+		})
+	}
+
+	return findings
+}
+
+// dangerousGrants summarizes the dangerous commands u may run per category,
+// or lists the categories granted outright when COMMAND output is missing.
+func dangerousGrants(u ACLUser, commands map[string][]string) []aclGrant {
+	var granted []aclGrant
+	if len(commands) > 0 {
+		for _, dc := range dangerousCategories {
+			var names []string
+			for name, cats := range commands {
+				if inCategories(cats, dc) && u.Allows(name, cats) {
+					names = append(names, strings.ToUpper(name))
+				}
+			}
+			if len(names) == 0 {
+				continue
+			}
+			sort.Strings(names)
+			g := aclGrant{Category: dc, Commands: len(names), Examples: names}
+			if len(g.Examples) > aclGrantExamples {
+				g.Examples = g.Examples[:aclGrantExamples]
+			}
+			granted = append(granted, g)
+		}
+		return granted
+	}
+
+	// Without COMMAND output, fall back to explicit category grants.
+	for _, dc := range dangerousCategories {
+		if hasRule(u, "+@"+dc) {
+			granted = append(granted, aclGrant{Category: dc})
+		}
+	}
+	return granted
+}
+
+func hasRule(u ACLUser, rules ...string) bool {
+	for _, r := range u.CommandRules {
+		for _, want := range rules {
+			if r == want {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isUnknownCommand reports whether err is Redis rejecting a command it does
+// not implement.
+func isUnknownCommand(err error) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), "unknown command")
+}
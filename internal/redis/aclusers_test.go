@@ -0,0 +1,135 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestACLUserScanner_Name(t *testing.T) {
+	s := &ACLUserScanner{}
+	if s.Name() != "acl_users" {
+		t.Errorf("expected name 'acl_users', got %q", s.Name())
+	}
+}
+
+func TestACLUserScanner_Findings(t *testing.T) {
+	mock := newMockClient()
+	mock.aclList = []string{
+		"user admin on #a1 ~* &* +@all",
+		"user app on #a2 ~app:* &* -@all +@read +@write",
+		"user ops on #a3 #a4 ~* &* -@all +@read +@admin",
+		"user ghost on nopass ~* &* -@all +get",
+		"user retired off #a5 ~* &* +@all",
+		"user default on #a6 ~* &* +@all",
+	}
+	mock.commands = map[string][]string{
+		"get":      {"@read", "@string", "@fast"},
+		"set":      {"@write", "@string", "@slow"},
+		"config":   {"@admin", "@slow", "@dangerous"},
+		"flushall": {"@keyspace", "@write", "@slow", "@dangerous"},
+	}
+	mock.clientList = "id=1 addr=10.0.0.1:5000 name= user=admin\nid=2 addr=10.0.0.2:5000 name= user=app\nid=3 addr=10.0.0.3:5000 name= user=default\n"
+	mock.aclLog = []ACLLogEntry{{Count: 3, Reason: "auth", Object: "AUTH", Username: "ghost"}}
+
+	s := &ACLUserScanner{}
+	cfg := AuditConfig{inventory: &inventory{data: make(map[string]any)}}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string][]FindingID)
+	for _, f := range findings {
+		got[f.ResourceID] = append(got[f.ResourceID], f.ID)
+		if _, ok := f.Metadata["acl"].(ACLUser); !ok {
+			t.Errorf("%s: expected structured acl metadata", f.ID)
+		}
+	}
+
+	want := map[string][]FindingID{
+		"admin":   {FindingACLOverprivileged},
+		"default": {FindingACLOverprivileged},
+		"app":     {FindingACLDangerousAccess},
+		"ops":     {FindingACLDangerousAccess, FindingACLMultiplePasswords, FindingACLUserNotConnected},
+		"ghost":   {FindingACLNoPassword, FindingACLUserNotConnected},
+	}
+	for user, ids := range want {
+		if len(got[user]) != len(ids) {
+			t.Errorf("user %s: expected %v, got %v", user, ids, got[user])
+			continue
+		}
+		for i := range ids {
+			if got[user][i] != ids[i] {
+				t.Errorf("user %s: expected %v, got %v", user, ids, got[user])
+				break
+			}
+		}
+	}
+	if _, ok := got["retired"]; ok {
+		t.Error("disabled users should not produce findings")
+	}
+
+	users, ok := cfg.inventory.data["acl_users"].([]ACLUser)
+	if !ok || len(users) != 6 {
+		t.Errorf("expected 6 ACL users in inventory, got %v", cfg.inventory.data["acl_users"])
+	}
+}
+
+func TestACLUserScanner_ClientListError(t *testing.T) {
+	mock := newMockClient()
+	mock.aclList = []string{"user batch on #a1 ~batch:* &* -@all +@read"}
+	mock.clientListErr = errors.New("NOPERM this user has no permissions to run the 'client|list' command")
+
+	cfg := AuditConfig{skipped: &skipList{}}
+	findings, err := (&ACLUserScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findingsByID(findings)[FindingACLUserNotConnected]) != 0 {
+		t.Errorf("expected no connection findings without CLIENT LIST, got %v", findings)
+	}
+	if len(cfg.skipped.checks) != 1 || cfg.skipped.checks[0].Check != string(FindingACLUserNotConnected) {
+		t.Errorf("expected the connection check to be skipped, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestACLUserScanner_PreACLServer(t *testing.T) {
+	mock := newMockClient()
+	mock.aclErr = errors.New("ERR unknown command 'ACL', with args beginning with: 'LIST'")
+
+	s := &ACLUserScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestDangerousGrants_Summarized(t *testing.T) {
+	u, err := ParseACLUser("user app on #a1 ~app:* &* +@all")
+	if err != nil {
+		t.Fatal(err)
+	}
+	commands := make(map[string][]string)
+	for i := 0; i < 120; i++ {
+		commands[fmt.Sprintf("cmd%03d", i)] = []string{"@admin", "@dangerous"}
+	}
+	commands["get"] = []string{"@read"}
+
+	granted := dangerousGrants(u, commands)
+	if len(granted) != 2 {
+		t.Fatalf("expected one entry per dangerous category, got %v", granted)
+	}
+	for _, g := range granted {
+		if g.Commands != 120 || len(g.Examples) != aclGrantExamples {
+			t.Errorf("@%s: expected 120 commands with %d examples, got %d and %d", g.Category, aclGrantExamples, g.Commands, len(g.Examples))
+		}
+	}
+	if got := granted[0].String(); got != "@dangerous (120 commands: CMD000, CMD001, CMD002, ...)" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// cacheMinUptime skips judging counters of a freshly restarted instance.
+	cacheMinUptime = time.Hour
+	// cacheMinLookups is the number of keyspace lookups needed to judge the
+	// hit ratio.
+	cacheMinLookups = 10000
+	// hitRatioLow and hitRatioVeryLow are hit ratios reported as medium and high.
+	hitRatioLow     = 0.8
+	hitRatioVeryLow = 0.5
+	// evictionChurnShare flags caches that evict this share of their keys
+	// per hour; evictionChurnMin skips small eviction counts.
+	evictionChurnShare = 0.5
+	evictionChurnMin   = 1000
+	// staleExpiredPercent is the expired_stale_perc reported as expired keys
+	// lingering in memory.
+	staleExpiredPercent = 10
+)
+
+// CacheScanner audits cache effectiveness: keyspace hit ratio, eviction
+// churn, and expired keys that have not been reclaimed.
+type CacheScanner struct{}
+
+func (s *CacheScanner) Name() string { return "cache" }
+
+func (s *CacheScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	serverRaw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	statsRaw, err := client.Info(ctx, "stats")
+	if err != nil {
+		return nil, fmt.Errorf("info stats: %w", err)
+	}
+	keyspaceRaw, err := client.Info(ctx, "keyspace")
+	if err != nil {
+		return nil, fmt.Errorf("info keyspace: %w", err)
+	}
+	policy, err := maxmemoryPolicy(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := ParseInfo(statsRaw)
+	server := ParseInfo(serverRaw)
+	counters := countersFor(cfg, server, stats)
+	uptime := counters.Uptime()
+	keys, expires := keyspaceTotals(ParseInfo(keyspaceRaw))
+
+	hitsRate := counters.Rate("keyspace_hits")
+	hits := hitsRate.Count
+	misses := counters.Rate("keyspace_misses").Count
+	evicted := counters.Rate("evicted_keys")
+	expired := counters.Rate("expired_keys")
+	stalePerc := infoFloat(stats, "expired_stale_perc")
+
+	var hitRatio float64
+	if hits+misses > 0 {
+		hitRatio = float64(hits) / float64(hits+misses)
+	}
+	evictionsPerHour := evicted.PerHour()
+	expirationsPerHour := expired.PerHour()
+
+	cache := isCache(policy, keys, expires)
+	cfg.inventory.set("cache", map[string]any{
+		"is_cache":             cache,
+		"hit_ratio":            hitRatio,
+		"keyspace_hits":        hits,
+		"keyspace_misses":      misses,
+		"evictions_per_hour":   evictionsPerHour,
+		"expirations_per_hour": expirationsPerHour,
+		"expired_stale_perc":   stalePerc,
+		"uptime_seconds":       int64(uptime.Seconds()),
+		"counter_window":       hitsRate.Source,
+	})
+
+	if !cache || uptime < cacheMinUptime {
+		return nil, nil
+	}
+
+	var findings []Finding
+	if hits+misses >= cacheMinLookups && hitRatio < hitRatioLow {
+		severity := SeverityMedium
+		if hitRatio < hitRatioVeryLow {
+			severity = SeverityHigh
+		}
+		findings = append(findings, Finding{
+			ID:           FindingLowHitRatio,
+			Severity:     severity,
+			ResourceType: "Cache",
+			ResourceID:   cfg.Addr,
+			Message: fmt.Sprintf("cache hit ratio %.1f%% (%d hits, %d misses %s)",
+				hitRatio*100, hits, misses, hitsRate.Describe()),
+			Metadata: map[string]any{
+				"hit_ratio":       hitRatio,
+				"keyspace_hits":   hits,
+				"keyspace_misses": misses,
+				"uptime_seconds":  int64(uptime.Seconds()),
+				"recommendation":  "check TTLs against access patterns, key naming mismatches between writers and readers, and whether evictions remove keys before they are read",
+			},
+		})
+	}
+
+	if keys > 0 && evicted.Count >= evictionChurnMin && evictionsPerHour >= evictionChurnShare*float64(keys) {
+		findings = append(findings, Finding{
+			ID:           FindingEvictionChurn,
+			Severity:     SeverityMedium,
+			ResourceType: "Cache",
+			ResourceID:   cfg.Addr,
+			Message: fmt.Sprintf("%.0f evictions per hour against %d keys; the cache turns over every %.1f hours",
+				evictionsPerHour, keys, float64(keys)/evictionsPerHour),
+			Metadata: map[string]any{
+				"evicted_keys":       evicted.Count,
+				"evictions_per_hour": evictionsPerHour,
+				"keys":               keys,
+				"policy":             policy,
+				"recommendation":     "raise maxmemory or shorten TTLs so keys expire before they are evicted",
+			},
+		})
+	}
+
+	if stalePerc >= staleExpiredPercent {
+		findings = append(findings, Finding{
+			ID:           FindingStaleExpiredKeys,
+			Severity:     SeverityLow,
+			ResourceType: "Cache",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("an estimated %.1f%% of keys are expired but still in memory", stalePerc),
+			Metadata: map[string]any{
+				"expired_stale_perc":   stalePerc,
+				"expirations_per_hour": expirationsPerHour,
+				"recommendation":       "raise hz or active-expire-effort so the expire cycle reclaims expired keys sooner",
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+// isCache reports whether an instance behaves like a cache: it evicts under
+// memory pressure or most of its keys carry a TTL.
+func isCache(policy string, keys, expires int64) bool {
+	if strings.HasPrefix(policy, "allkeys-") || strings.HasPrefix(policy, "volatile-") {
+		return true
+	}
+	return keys > 0 && expires*2 >= keys
+}
+
+// formatUptime renders an uptime in whole hours or days.
+func formatUptime(d time.Duration) string {
+	if d >= 48*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+	return fmt.Sprintf("%dh", int(d.Hours()))
+}
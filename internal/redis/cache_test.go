@@ -0,0 +1,96 @@
+package redis
+
+import (
+	"context"
+	"testing"
+)
+
+func cacheMock(uptime, stats, keyspace, policy string) *mockClient {
+	mock := newMockClient()
+	mock.infoResponses["server"] = "uptime_in_seconds:" + uptime + "\r\n"
+	mock.infoResponses["stats"] = stats
+	mock.infoResponses["keyspace"] = keyspace
+	mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": policy}
+	return mock
+}
+
+func TestCacheScanner_Name(t *testing.T) {
+	s := &CacheScanner{}
+	if s.Name() != "cache" {
+		t.Errorf("expected name 'cache', got %q", s.Name())
+	}
+}
+
+func TestCacheScanner_LowHitRatioAndChurn(t *testing.T) {
+	// One day of uptime, 40% hit ratio, 48000 evictions against 1000 keys.
+	mock := cacheMock("86400",
+		"keyspace_hits:40000\r\nkeyspace_misses:60000\r\nevicted_keys:48000\r\nexpired_keys:1000\r\nexpired_stale_perc:25.00\r\n",
+		"db0:keys=1000,expires=0,avg_ttl=0\r\n", "allkeys-lru")
+	cfg := AuditConfig{Addr: "localhost:6379", inventory: &inventory{data: make(map[string]any)}}
+
+	s := &CacheScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	if f := byID[FindingLowHitRatio]; len(f) != 1 || f[0].Severity != SeverityHigh {
+		t.Errorf("expected high LOW_HIT_RATIO, got %+v", f)
+	}
+	if f := byID[FindingEvictionChurn]; len(f) != 1 {
+		t.Errorf("expected EVICTION_CHURN, got %+v", findings)
+	}
+	if f := byID[FindingStaleExpiredKeys]; len(f) != 1 {
+		t.Errorf("expected STALE_EXPIRED_KEYS, got %+v", findings)
+	}
+
+	inv := cfg.inventory.data["cache"].(map[string]any)
+	if inv["hit_ratio"] != 0.4 || inv["evictions_per_hour"] != 2000.0 {
+		t.Errorf("unexpected cache inventory: %v", inv)
+	}
+}
+
+func TestCacheScanner_FreshRestart(t *testing.T) {
+	mock := cacheMock("600",
+		"keyspace_hits:10\r\nkeyspace_misses:90000\r\nevicted_keys:50000\r\n",
+		"db0:keys=1000,expires=0,avg_ttl=0\r\n", "allkeys-lru")
+
+	s := &CacheScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings within the first hour of uptime, got %+v", findings)
+	}
+}
+
+func TestCacheScanner_NotACache(t *testing.T) {
+	mock := cacheMock("86400",
+		"keyspace_hits:10\r\nkeyspace_misses:90000\r\n",
+		"db0:keys=1000,expires=10,avg_ttl=0\r\n", "noeviction")
+
+	s := &CacheScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings for a non-cache instance, got %+v", findings)
+	}
+}
+
+func TestCacheScanner_HealthyCache(t *testing.T) {
+	mock := cacheMock("86400",
+		"keyspace_hits:95000\r\nkeyspace_misses:5000\r\nevicted_keys:100\r\nexpired_stale_perc:1.00\r\n",
+		"db0:keys=100000,expires=100000,avg_ttl=60000\r\n", "noeviction")
+
+	s := &CacheScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %+v", findings)
+	}
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	goredis "github.com/redis/go-redis/v9"
@@ -14,19 +15,81 @@ type RedisClient interface {
 	Info(ctx context.Context, sections ...string) (string, error)
 	Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error)
 	ObjectIdleTime(ctx context.Context, key string) (time.Duration, error)
+	ObjectFreq(ctx context.Context, key string) (int64, error)
 	MemoryUsage(ctx context.Context, key string) (int64, error)
+	Type(ctx context.Context, key string) (string, error)
+	StrLen(ctx context.Context, key string) (int64, error)
+	GetRange(ctx context.Context, key string, start, end int64) (string, error)
+	HScan(ctx context.Context, key string, cursor uint64, match string, count int64) ([]string, uint64, error)
+	HLen(ctx context.Context, key string) (int64, error)
 	SlowLogGet(ctx context.Context, num int64) ([]SlowLogEntry, error)
 	ConfigGet(ctx context.Context, parameter string) (map[string]string, error)
 	DBSize(ctx context.Context) (int64, error)
+	ACLList(ctx context.Context) ([]string, error)
+	ACLLog(ctx context.Context, count int64) ([]ACLLogEntry, error)
+	ClientList(ctx context.Context) (string, error)
+	// Commands returns each command name with its ACL categories.
+	Commands(ctx context.Context) (map[string][]string, error)
+	LatencyLatest(ctx context.Context) ([]LatencyEvent, error)
+	LatencyHistory(ctx context.Context, event string) ([]LatencySample, error)
+	LatencyDoctor(ctx context.Context) (string, error)
+	// MemoryStats returns MEMORY STATS as a field map; nested per-database
+	// entries ("db.0") are maps as well.
+	MemoryStats(ctx context.Context) (map[string]any, error)
+	MallocStats(ctx context.Context) (string, error)
+	ModuleList(ctx context.Context) ([]ModuleInfo, error)
+	// FTList, FTInfo, and TSInfo are RediSearch and RedisTimeSeries
+	// commands; FTInfo and TSInfo return their replies as field maps.
+	FTList(ctx context.Context) ([]string, error)
+	FTInfo(ctx context.Context, index string) (map[string]any, error)
+	TSInfo(ctx context.Context, key string) (map[string]any, error)
+	ClusterInfo(ctx context.Context) (string, error)
+	ClusterNodes(ctx context.Context) (string, error)
 	Close() error
 }
 
+// ModuleInfo is a module loaded into the server, from MODULE LIST.
+type ModuleInfo struct {
+	Name    string
+	Version int64
+	Path    string
+}
+
 // SlowLogEntry represents a single slow log entry from Redis.
 type SlowLogEntry struct {
 	ID       int64
 	Time     time.Time
 	Duration time.Duration
 	Args     []string
+	// ClientAddr and ClientName identify the client that ran the command
+	// (Redis 4.0+).
+	ClientAddr string
+	ClientName string
+}
+
+// ACLLogEntry is a single ACL LOG entry: a denied command, key, channel,
+// or failed authentication.
+type ACLLogEntry struct {
+	Count      int64
+	Reason     string
+	Object     string
+	Username   string
+	AgeSeconds float64
+}
+
+// LatencyEvent is one LATENCY LATEST entry: the most recent and the
+// all-time maximum latency recorded for an event such as "fork".
+type LatencyEvent struct {
+	Name   string
+	Time   time.Time
+	Latest time.Duration
+	Max    time.Duration
+}
+
+// LatencySample is one LATENCY HISTORY entry.
+type LatencySample struct {
+	Time    time.Time
+	Latency time.Duration
 }
 
 // GoRedisClient wraps go-redis/v9 and implements RedisClient.
@@ -56,6 +119,10 @@ func (c *GoRedisClient) Scan(ctx context.Context, cursor uint64, match string, c
 	return c.client.Scan(ctx, cursor, match, count).Result()
 }
 
+func (c *GoRedisClient) ObjectFreq(ctx context.Context, key string) (int64, error) {
+	return c.client.ObjectFreq(ctx, key).Result()
+}
+
 func (c *GoRedisClient) ObjectIdleTime(ctx context.Context, key string) (time.Duration, error) {
 	return c.client.ObjectIdleTime(ctx, key).Result()
 }
@@ -64,6 +131,26 @@ func (c *GoRedisClient) MemoryUsage(ctx context.Context, key string) (int64, err
 	return c.client.MemoryUsage(ctx, key).Result()
 }
 
+func (c *GoRedisClient) Type(ctx context.Context, key string) (string, error) {
+	return c.client.Type(ctx, key).Result()
+}
+
+func (c *GoRedisClient) StrLen(ctx context.Context, key string) (int64, error) {
+	return c.client.StrLen(ctx, key).Result()
+}
+
+func (c *GoRedisClient) GetRange(ctx context.Context, key string, start, end int64) (string, error) {
+	return c.client.GetRange(ctx, key, start, end).Result()
+}
+
+func (c *GoRedisClient) HScan(ctx context.Context, key string, cursor uint64, match string, count int64) ([]string, uint64, error) {
+	return c.client.HScan(ctx, key, cursor, match, count).Result()
+}
+
+func (c *GoRedisClient) HLen(ctx context.Context, key string) (int64, error) {
+	return c.client.HLen(ctx, key).Result()
+}
+
 func (c *GoRedisClient) SlowLogGet(ctx context.Context, num int64) ([]SlowLogEntry, error) {
 	result, err := c.client.SlowLogGet(ctx, num).Result()
 	if err != nil {
@@ -72,10 +159,12 @@ func (c *GoRedisClient) SlowLogGet(ctx context.Context, num int64) ([]SlowLogEnt
 	entries := make([]SlowLogEntry, len(result))
 	for i, r := range result {
 		entries[i] = SlowLogEntry{
-			ID:       r.ID,
-			Time:     r.Time,
-			Duration: r.Duration,
-			Args:     r.Args,
+			ID:         r.ID,
+			Time:       r.Time,
+			Duration:   r.Duration,
+			Args:       r.Args,
+			ClientAddr: r.ClientAddr,
+			ClientName: r.ClientName,
 		}
 	}
 	return entries, nil
@@ -89,6 +178,160 @@ func (c *GoRedisClient) DBSize(ctx context.Context) (int64, error) {
 	return c.client.DBSize(ctx).Result()
 }
 
+func (c *GoRedisClient) ACLList(ctx context.Context) ([]string, error) {
+	return c.client.ACLList(ctx).Result()
+}
+
+func (c *GoRedisClient) ACLLog(ctx context.Context, count int64) ([]ACLLogEntry, error) {
+	result, err := c.client.ACLLog(ctx, count).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]ACLLogEntry, len(result))
+	for i, r := range result {
+		entries[i] = ACLLogEntry{
+			Count:      r.Count,
+			Reason:     r.Reason,
+			Object:     r.Object,
+			Username:   r.Username,
+			AgeSeconds: r.AgeSeconds,
+		}
+	}
+	return entries, nil
+}
+
+func (c *GoRedisClient) ClientList(ctx context.Context) (string, error) {
+	return c.client.ClientList(ctx).Result()
+}
+
+func (c *GoRedisClient) Commands(ctx context.Context) (map[string][]string, error) {
+	infos, err := c.client.Command(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+	commands := make(map[string][]string, len(infos))
+	for name, info := range infos {
+		commands[strings.ToLower(name)] = info.ACLFlags
+	}
+	return commands, nil
+}
+
+func (c *GoRedisClient) LatencyLatest(ctx context.Context) ([]LatencyEvent, error) {
+	result, err := c.client.Latency(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+	events := make([]LatencyEvent, len(result))
+	for i, r := range result {
+		events[i] = LatencyEvent{
+			Name:   r.Name,
+			Time:   r.Time,
+			Latest: r.Latest,
+			Max:    r.Max,
+		}
+	}
+	return events, nil
+}
+
+func (c *GoRedisClient) LatencyHistory(ctx context.Context, event string) ([]LatencySample, error) {
+	result, err := c.client.Do(ctx, "latency", "history", event).Slice()
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]LatencySample, 0, len(result))
+	for _, r := range result {
+		pair, ok := r.([]any)
+		if !ok || len(pair) < 2 {
+			continue
+		}
+		ts, _ := pair[0].(int64)
+		ms, _ := pair[1].(int64)
+		samples = append(samples, LatencySample{
+			Time:    time.Unix(ts, 0),
+			Latency: time.Duration(ms) * time.Millisecond,
+		})
+	}
+	return samples, nil
+}
+
+func (c *GoRedisClient) LatencyDoctor(ctx context.Context) (string, error) {
+	return c.client.Do(ctx, "latency", "doctor").Text()
+}
+
+func (c *GoRedisClient) MemoryStats(ctx context.Context) (map[string]any, error) {
+	reply, err := c.client.Do(ctx, "memory", "stats").Result()
+	if err != nil {
+		return nil, err
+	}
+	stats, ok := replyMap(reply)
+	if !ok {
+		return nil, fmt.Errorf("unexpected MEMORY STATS reply %T", reply)
+	}
+	for k, v := range stats {
+		if nested, ok := replyMap(v); ok {
+			stats[k] = nested
+		}
+	}
+	return stats, nil
+}
+
+func (c *GoRedisClient) MallocStats(ctx context.Context) (string, error) {
+	return c.client.Do(ctx, "memory", "malloc-stats").Text()
+}
+
+func (c *GoRedisClient) ModuleList(ctx context.Context) ([]ModuleInfo, error) {
+	reply, err := c.client.Do(ctx, "module", "list").Slice()
+	if err != nil {
+		return nil, err
+	}
+	modules := make([]ModuleInfo, 0, len(reply))
+	for _, r := range reply {
+		m, ok := replyMap(r)
+		if !ok {
+			return nil, fmt.Errorf("unexpected MODULE LIST entry %T", r)
+		}
+		modules = append(modules, ModuleInfo{
+			Name:    replyString(m["name"]),
+			Version: replyInt(m["ver"]),
+			Path:    replyString(m["path"]),
+		})
+	}
+	return modules, nil
+}
+
+func (c *GoRedisClient) FTList(ctx context.Context) ([]string, error) {
+	return c.client.Do(ctx, "FT._LIST").StringSlice()
+}
+
+func (c *GoRedisClient) FTInfo(ctx context.Context, index string) (map[string]any, error) {
+	return c.doMap(ctx, "FT.INFO", index)
+}
+
+func (c *GoRedisClient) TSInfo(ctx context.Context, key string) (map[string]any, error) {
+	return c.doMap(ctx, "TS.INFO", key)
+}
+
+func (c *GoRedisClient) ClusterInfo(ctx context.Context) (string, error) {
+	return c.client.ClusterInfo(ctx).Result()
+}
+
+func (c *GoRedisClient) ClusterNodes(ctx context.Context) (string, error) {
+	return c.client.ClusterNodes(ctx).Result()
+}
+
+// doMap sends a command whose reply is a field map.
+func (c *GoRedisClient) doMap(ctx context.Context, args ...any) (map[string]any, error) {
+	reply, err := c.client.Do(ctx, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := replyMap(reply)
+	if !ok {
+		return nil, fmt.Errorf("unexpected %v reply %T", args[0], reply)
+	}
+	return m, nil
+}
+
 func (c *GoRedisClient) Close() error {
 	return c.client.Close()
 }
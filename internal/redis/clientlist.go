@@ -0,0 +1,80 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ClientInfo is one connection from CLIENT LIST.
+type ClientInfo struct {
+	ID      int64
+	Addr    string
+	Name    string
+	User    string
+	LibName string
+	LibVer  string
+	Flags   string
+	Cmd     string
+	Age     int64 // seconds since connect
+	Idle    int64 // seconds since last command
+	QBuf    int64 // query buffer bytes
+	OMem    int64 // output buffer bytes
+	TotMem  int64 // total memory used by the client
+}
+
+// IP returns the source address without the port.
+func (c ClientInfo) IP() string {
+	if i := strings.LastIndexByte(c.Addr, ':'); i >= 0 {
+		return c.Addr[:i]
+	}
+	return c.Addr
+}
+
+// ParseClientList parses CLIENT LIST output: one client per line, each a
+// space-separated list of field=value pairs.
+func ParseClientList(raw string) []ClientInfo {
+	var clients []ClientInfo
+	for _, line := range strings.Split(raw, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var c ClientInfo
+		for _, field := range strings.Fields(line) {
+			k, v, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			switch k {
+			case "id":
+				c.ID, _ = strconv.ParseInt(v, 10, 64)
+			case "addr":
+				c.Addr = v
+			case "name":
+				c.Name = v
+			case "user":
+				c.User = v
+			case "lib-name":
+				c.LibName = v
+			case "lib-ver":
+				c.LibVer = v
+			case "flags":
+				c.Flags = v
+			case "cmd":
+				c.Cmd = v
+			case "age":
+				c.Age, _ = strconv.ParseInt(v, 10, 64)
+			case "idle":
+				c.Idle, _ = strconv.ParseInt(v, 10, 64)
+			case "qbuf":
+				c.QBuf, _ = strconv.ParseInt(v, 10, 64)
+			case "omem":
+				c.OMem, _ = strconv.ParseInt(v, 10, 64)
+			case "tot-mem":
+				c.TotMem, _ = strconv.ParseInt(v, 10, 64)
+			}
+		}
+		clients = append(clients, c)
+	}
+	return clients
+}
@@ -0,0 +1,36 @@
+package redis
+
+import "testing"
+
+func TestParseClientList(t *testing.T) {
+	raw := "id=3 addr=10.0.0.5:52110 laddr=10.0.0.1:6379 fd=8 name=worker age=120 idle=60 flags=N db=0 sub=0 psub=0 ssub=0 multi=-1 watch=0 qbuf=26 qbuf-free=20448 argv-mem=10 multi-mem=0 rbs=1024 rbp=0 obl=0 oll=0 omem=0 tot-mem=22426 events=r cmd=client|list user=app redir=-1 resp=2 lib-name=redis-py lib-ver=5.0.1\n" +
+		"id=4 addr=[::1]:40000 name= age=5 idle=0 flags=N qbuf=0 omem=1048576 tot-mem=1070000 cmd=get user=default\n"
+
+	clients := ParseClientList(raw)
+	if len(clients) != 2 {
+		t.Fatalf("expected 2 clients, got %d", len(clients))
+	}
+
+	c := clients[0]
+	if c.ID != 3 || c.Name != "worker" || c.User != "app" || c.LibName != "redis-py" || c.LibVer != "5.0.1" {
+		t.Errorf("unexpected client: %+v", c)
+	}
+	if c.Age != 120 || c.Idle != 60 || c.QBuf != 26 || c.TotMem != 22426 || c.Cmd != "client|list" {
+		t.Errorf("unexpected numeric fields: %+v", c)
+	}
+	if c.IP() != "10.0.0.5" {
+		t.Errorf("expected IP 10.0.0.5, got %q", c.IP())
+	}
+	if clients[1].IP() != "[::1]" {
+		t.Errorf("expected IP [::1], got %q", clients[1].IP())
+	}
+	if clients[1].OMem != 1048576 {
+		t.Errorf("expected omem 1048576, got %d", clients[1].OMem)
+	}
+}
+
+func TestParseClientListEmpty(t *testing.T) {
+	if clients := ParseClientList(""); len(clients) != 0 {
+		t.Errorf("expected 0 clients, got %d", len(clients))
+	}
+}
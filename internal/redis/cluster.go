@@ -0,0 +1,675 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// clusterSlots is the number of hash slots in a Redis Cluster.
+	clusterSlots = 16384
+	// defaultShardImbalanceRatio is the largest shard over the shard average
+	// reported as imbalanced.
+	defaultShardImbalanceRatio = 1.5
+	// shardMinKeys, shardMinMemory, and shardMinOps skip the imbalance check
+	// for a metric when even the largest shard is below them.
+	shardMinKeys   = 1000
+	shardMinMemory = 64 << 20 // 64 MB
+	shardMinOps    = 100
+	// hashTagMinKeys and hashTagShare are the sampled keys sharing one hash
+	// tag, as a count and as a share of the sample, reported as a hotspot.
+	hashTagMinKeys = 100
+	hashTagShare   = 0.1
+	// clusterListMax caps slot ranges and examples kept in metadata.
+	clusterListMax = 10
+)
+
+// slotRange is an inclusive range of hash slots.
+type slotRange struct {
+	Start, End int
+}
+
+func (r slotRange) String() string {
+	if r.Start == r.End {
+		return strconv.Itoa(r.Start)
+	}
+	return fmt.Sprintf("%d-%d", r.Start, r.End)
+}
+
+// clusterNode is one line of CLUSTER NODES. Migrating and Importing map
+// slots to the peer node ID; a node only reports them for itself.
+type clusterNode struct {
+	ID        string
+	Addr      string
+	Flags     map[string]bool
+	MasterID  string
+	Slots     []slotRange
+	Migrating map[int]string
+	Importing map[int]string
+}
+
+// slotCount returns the number of slots the node serves.
+func (n clusterNode) slotCount() int {
+	count := 0
+	for _, r := range n.Slots {
+		count += r.End - r.Start + 1
+	}
+	return count
+}
+
+func (n clusterNode) failing() bool {
+	return n.Flags["fail"] || n.Flags["noaddr"] || n.Flags["handshake"]
+}
+
+// parseClusterNodes parses CLUSTER NODES output:
+//
+//	<id> <ip:port@cport[,hostname]> <flags> <master> <ping> <pong> <epoch> <link> <slot>...
+//
+// Slots are single ("42"), ranges ("0-5460"), or open migrations
+// ("[42->-<id>]" migrating, "[42-<-<id>]" importing).
+func parseClusterNodes(raw string) []clusterNode {
+	var nodes []clusterNode
+	for _, line := range strings.Split(raw, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 8 {
+			continue
+		}
+		addr, _, _ := strings.Cut(fields[1], "@")
+		addr, _, _ = strings.Cut(addr, ",")
+		n := clusterNode{
+			ID:        fields[0],
+			Addr:      addr,
+			Flags:     make(map[string]bool),
+			Migrating: make(map[int]string),
+			Importing: make(map[int]string),
+		}
+		for _, flag := range strings.Split(fields[2], ",") {
+			n.Flags[flag] = true
+		}
+		if fields[3] != "-" {
+			n.MasterID = fields[3]
+		}
+		for _, slot := range fields[8:] {
+			if strings.HasPrefix(slot, "[") {
+				body := strings.Trim(slot, "[]")
+				if s, peer, ok := strings.Cut(body, "->-"); ok {
+					if v, err := strconv.Atoi(s); err == nil {
+						n.Migrating[v] = peer
+					}
+				} else if s, peer, ok := strings.Cut(body, "-<-"); ok {
+					if v, err := strconv.Atoi(s); err == nil {
+						n.Importing[v] = peer
+					}
+				}
+				continue
+			}
+			start, end, isRange := strings.Cut(slot, "-")
+			if !isRange {
+				end = start
+			}
+			a, errA := strconv.Atoi(start)
+			b, errB := strconv.Atoi(end)
+			if errA == nil && errB == nil {
+				n.Slots = append(n.Slots, slotRange{a, b})
+			}
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes
+}
+
+// hashTag returns the part of a key between the first "{" and the next "}",
+// which Redis Cluster hashes instead of the whole key when it is not empty.
+func hashTag(key string) (string, bool) {
+	open := strings.IndexByte(key, '{')
+	if open < 0 {
+		return "", false
+	}
+	end := strings.IndexByte(key[open+1:], '}')
+	if end <= 0 {
+		return "", false
+	}
+	return key[open+1 : open+1+end], true
+}
+
+// keySlot returns the hash slot of a key: CRC16 (XMODEM) of the key or its
+// hash tag, modulo 16384.
+func keySlot(key string) int {
+	if tag, ok := hashTag(key); ok {
+		key = tag
+	}
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return int(crc % clusterSlots)
+}
+
+// clusterShard is a master serving slots, with the metrics read from it.
+type clusterShard struct {
+	Node     clusterNode
+	Replicas int
+	// Self is the shard's own CLUSTER NODES line, the only one that shows
+	// its open slot migrations; nil when the shard could not be read.
+	Self       *clusterNode
+	Keys       int64
+	UsedMemory int64
+	Ops        int64
+}
+
+func (sh *clusterShard) read(ctx context.Context, client RedisClient) error {
+	memoryRaw, err := client.Info(ctx, "memory")
+	if err != nil {
+		return fmt.Errorf("info memory: %w", err)
+	}
+	statsRaw, err := client.Info(ctx, "stats")
+	if err != nil {
+		return fmt.Errorf("info stats: %w", err)
+	}
+	keyspaceRaw, err := client.Info(ctx, "keyspace")
+	if err != nil {
+		return fmt.Errorf("info keyspace: %w", err)
+	}
+	nodesRaw, err := client.ClusterNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("cluster nodes: %w", err)
+	}
+
+	sh.UsedMemory = infoInt(ParseInfo(memoryRaw), "used_memory")
+	sh.Ops = infoInt(ParseInfo(statsRaw), "instantaneous_ops_per_sec")
+	sh.Keys, _ = keyspaceTotals(ParseInfo(keyspaceRaw))
+	for _, n := range parseClusterNodes(nodesRaw) {
+		if n.Flags["myself"] {
+			self := n
+			sh.Self = &self
+		}
+	}
+	return nil
+}
+
+// ClusterScanner audits Redis Cluster slot health and how evenly data and
+// traffic are spread across shards. It reports nothing for standalone
+// servers.
+type ClusterScanner struct{}
+
+func (s *ClusterScanner) Name() string { return "cluster" }
+
+func (s *ClusterScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.Info(ctx, "cluster")
+	if err != nil {
+		return nil, fmt.Errorf("info cluster: %w", err)
+	}
+	if ParseInfo(raw)["cluster_enabled"] != "1" {
+		return nil, nil
+	}
+
+	infoRaw, err := client.ClusterInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cluster info: %w", err)
+	}
+	info := ParseInfo(infoRaw)
+	nodesRaw, err := client.ClusterNodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("cluster nodes: %w", err)
+	}
+	nodes := parseClusterNodes(nodesRaw)
+
+	var fullCoverage string
+	if cfg.supports(CapConfig) {
+		conf, err := client.ConfigGet(ctx, "cluster-require-full-coverage")
+		if err != nil {
+			return nil, fmt.Errorf("config get cluster-require-full-coverage: %w", err)
+		}
+		fullCoverage = conf["cluster-require-full-coverage"]
+	} else {
+		cfg.skip(s.Name(), "cluster-require-full-coverage", CapConfig)
+	}
+
+	shards, err := s.readShards(ctx, client, cfg, nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+	if f, ok := s.coverage(cfg, info, nodes, fullCoverage); ok {
+		findings = append(findings, f)
+	}
+	if f, ok := s.coverageRisk(cfg, shards, fullCoverage); ok {
+		findings = append(findings, f)
+	}
+	findings = append(findings, s.openSlots(nodes, shards)...)
+	findings = append(findings, s.imbalance(cfg, shards)...)
+
+	if len(shards) > 1 {
+		hotspots, err := s.hashTags(ctx, client, cfg, shards)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, hotspots...)
+	}
+
+	inv := make([]map[string]any, 0, len(shards))
+	for _, sh := range shards {
+		inv = append(inv, map[string]any{
+			"id":          sh.Node.ID,
+			"addr":        sh.Node.Addr,
+			"slots":       sh.Node.slotCount(),
+			"replicas":    sh.Replicas,
+			"read":        sh.Self != nil,
+			"keys":        sh.Keys,
+			"used_memory": sh.UsedMemory,
+			"ops_per_sec": sh.Ops,
+		})
+	}
+	cfg.inventory.set("cluster", map[string]any{
+		"state":                 info["cluster_state"],
+		"known_nodes":           infoInt(info, "cluster_known_nodes"),
+		"slots_assigned":        infoInt(info, "cluster_slots_assigned"),
+		"require_full_coverage": fullCoverage,
+		"shards":                inv,
+	})
+
+	return findings, nil
+}
+
+// readShards returns the masters serving slots, ordered by their first
+// slot. The connected node is read through client, every other master
+// through cfg.DialShard; shards that cannot be read are recorded as a
+// skipped check and keep zero metrics.
+func (s *ClusterScanner) readShards(ctx context.Context, client RedisClient, cfg AuditConfig, nodes []clusterNode) ([]clusterShard, error) {
+	replicas := make(map[string]int)
+	for _, n := range nodes {
+		if n.MasterID != "" && !n.failing() {
+			replicas[n.MasterID]++
+		}
+	}
+
+	var shards []clusterShard
+	for _, n := range nodes {
+		if n.Flags["master"] && len(n.Slots) > 0 {
+			shards = append(shards, clusterShard{Node: n, Replicas: replicas[n.ID]})
+		}
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Node.Slots[0].Start < shards[j].Node.Slots[0].Start })
+
+	unread, err := s.eachShard(ctx, client, cfg, shards, func(sh *clusterShard, c RedisClient) error {
+		return sh.read(ctx, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(unread) > 0 {
+		reason := "could not read shards " + strings.Join(unread, ", ")
+		if cfg.DialShard == nil {
+			reason = "only the connected node is read without a shard dialer"
+		}
+		cfg.skipped.add(SkippedCheck{Auditor: s.Name(), Check: "shard_balance", Reason: reason})
+	}
+	return shards, nil
+}
+
+// eachShard calls fn for every shard with a client for its master: client
+// for the connected node, cfg.DialShard for the others. An error on the
+// connected node is returned; other masters that cannot be dialed or read
+// are returned as unread.
+func (s *ClusterScanner) eachShard(ctx context.Context, client RedisClient, cfg AuditConfig, shards []clusterShard, fn func(sh *clusterShard, c RedisClient) error) ([]string, error) {
+	var unread []string
+	for i := range shards {
+		sh := &shards[i]
+		if sh.Node.Flags["myself"] {
+			if err := fn(sh, client); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if cfg.DialShard == nil || sh.Node.failing() {
+			unread = append(unread, sh.Node.Addr)
+			continue
+		}
+		shardClient, err := cfg.DialShard(ctx, sh.Node.Addr)
+		if err != nil {
+			unread = append(unread, fmt.Sprintf("%s (%v)", sh.Node.Addr, err))
+			continue
+		}
+		err = fn(sh, shardClient)
+		_ = shardClient.Close()
+		if err != nil {
+			unread = append(unread, fmt.Sprintf("%s (%v)", sh.Node.Addr, err))
+		}
+	}
+	return unread, nil
+}
+
+func (s *ClusterScanner) coverage(cfg AuditConfig, info map[string]string, nodes []clusterNode, fullCoverage string) (Finding, bool) {
+	state := info["cluster_state"]
+	assigned := infoInt(info, "cluster_slots_assigned")
+	failing := infoInt(info, "cluster_slots_fail")
+	unassigned := clusterSlots - assigned
+	if unassigned <= 0 && failing == 0 && state == "ok" {
+		return Finding{}, false
+	}
+
+	var covered [clusterSlots]bool
+	for _, n := range nodes {
+		if !n.Flags["master"] {
+			continue
+		}
+		for _, r := range n.Slots {
+			for slot := max(r.Start, 0); slot <= min(r.End, clusterSlots-1); slot++ {
+				covered[slot] = true
+			}
+		}
+	}
+	var ranges []string
+	for slot := 0; slot < clusterSlots && len(ranges) < clusterListMax; slot++ {
+		if covered[slot] {
+			continue
+		}
+		r := slotRange{slot, slot}
+		for r.End+1 < clusterSlots && !covered[r.End+1] {
+			r.End++
+		}
+		ranges = append(ranges, r.String())
+		slot = r.End
+	}
+
+	// Without full coverage required, the cluster keeps serving the
+	// assigned slots.
+	severity := SeverityCritical
+	if fullCoverage == "no" {
+		severity = SeverityHigh
+	}
+	return Finding{
+		ID:           FindingClusterSlotsUncovered,
+		Severity:     severity,
+		ResourceType: "Cluster",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("cluster state is %s: %d of %d slots unassigned and %d served by failing nodes",
+			state, max(unassigned, 0), clusterSlots, failing),
+		Metadata: map[string]any{
+			"cluster_state":         state,
+			"slots_assigned":        assigned,
+			"slots_fail":            failing,
+			"slots_pfail":           infoInt(info, "cluster_slots_pfail"),
+			"unassigned_ranges":     ranges,
+			"require_full_coverage": fullCoverage,
+			"recommendation":        "assign the missing slots with redis-cli --cluster fix or restore the failing masters; with cluster-require-full-coverage yes the whole cluster refuses queries until every slot is served",
+		},
+	}, true
+}
+
+func (s *ClusterScanner) coverageRisk(cfg AuditConfig, shards []clusterShard, fullCoverage string) (Finding, bool) {
+	if fullCoverage != "yes" {
+		return Finding{}, false
+	}
+	var alone []string
+	for _, sh := range shards {
+		if sh.Replicas == 0 {
+			alone = append(alone, sh.Node.Addr)
+		}
+	}
+	if len(alone) == 0 {
+		return Finding{}, false
+	}
+	return Finding{
+		ID:           FindingClusterCoverageRisk,
+		Severity:     SeverityMedium,
+		ResourceType: "Cluster",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("cluster-require-full-coverage is yes and %d of %d masters have no replica; losing one stops the whole cluster",
+			len(alone), len(shards)),
+		Metadata: map[string]any{
+			"require_full_coverage":    fullCoverage,
+			"masters_without_replicas": alone,
+			"recommendation":           "add a replica to every master, or set cluster-require-full-coverage no so the other shards keep serving when one fails",
+		},
+	}, true
+}
+
+func (s *ClusterScanner) openSlots(nodes []clusterNode, shards []clusterShard) []Finding {
+	addrs := make(map[string]string, len(nodes))
+	for _, n := range nodes {
+		addrs[n.ID] = n.Addr
+	}
+	describe := func(slots map[int]string) []string {
+		keys := make([]int, 0, len(slots))
+		for slot := range slots {
+			keys = append(keys, slot)
+		}
+		sort.Ints(keys)
+		out := make([]string, 0, len(keys))
+		for _, slot := range keys {
+			peer := addrs[slots[slot]]
+			if peer == "" {
+				peer = slots[slot]
+			}
+			out = append(out, fmt.Sprintf("%d %s", slot, peer))
+		}
+		return out
+	}
+
+	var findings []Finding
+	for _, sh := range shards {
+		if sh.Self == nil || len(sh.Self.Migrating)+len(sh.Self.Importing) == 0 {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:           FindingClusterSlotMigrating,
+			Severity:     SeverityMedium,
+			ResourceType: "ClusterNode",
+			ResourceID:   sh.Node.Addr,
+			Message: fmt.Sprintf("node %s has %d slots migrating and %d importing",
+				sh.Node.Addr, len(sh.Self.Migrating), len(sh.Self.Importing)),
+			Metadata: map[string]any{
+				"node_id":        sh.Node.ID,
+				"migrating":      describe(sh.Self.Migrating),
+				"importing":      describe(sh.Self.Importing),
+				"recommendation": "if no resharding is running, a migration was interrupted; finish it with redis-cli --cluster fix, since clients get ASK redirects for keys in open slots",
+			},
+		})
+	}
+	return findings
+}
+
+// shardMetric is a per-shard quantity compared across shards.
+type shardMetric struct {
+	name           string
+	min            int64
+	value          func(clusterShard) int64
+	format         func(int64) string
+	recommendation string
+}
+
+var shardMetrics = []shardMetric{
+	{
+		name:           "keys",
+		min:            shardMinKeys,
+		value:          func(sh clusterShard) int64 { return sh.Keys },
+		format:         func(v int64) string { return fmt.Sprintf("%d keys", v) },
+		recommendation: "compare slot counts and rebalance with redis-cli --cluster rebalance; with even slots, look for hash tags concentrating keys",
+	},
+	{
+		name:           "used_memory",
+		min:            shardMinMemory,
+		value:          func(sh clusterShard) int64 { return sh.UsedMemory },
+		format:         FormatBytes,
+		recommendation: "look for big keys and hash tags on this shard, or move slots with redis-cli --cluster reshard",
+	},
+	{
+		name:           "ops_per_sec",
+		min:            shardMinOps,
+		value:          func(sh clusterShard) int64 { return sh.Ops },
+		format:         func(v int64) string { return fmt.Sprintf("%d ops/sec", v) },
+		recommendation: "look for hot keys on this shard (redis-cli --hotkeys under an LFU policy) and hash tags concentrating traffic",
+	},
+}
+
+func (s *ClusterScanner) imbalance(cfg AuditConfig, shards []clusterShard) []Finding {
+	if len(shards) < 2 {
+		return nil
+	}
+	for _, sh := range shards {
+		if sh.Self == nil {
+			return nil
+		}
+	}
+	threshold := cfg.ShardImbalanceRatio
+	if threshold <= 0 {
+		threshold = defaultShardImbalanceRatio
+	}
+
+	slots := make(map[string]int, len(shards))
+	for _, sh := range shards {
+		slots[sh.Node.Addr] = sh.Node.slotCount()
+	}
+
+	var findings []Finding
+	for _, m := range shardMetrics {
+		var sum int64
+		largest := shards[0]
+		perShard := make(map[string]int64, len(shards))
+		for _, sh := range shards {
+			v := m.value(sh)
+			sum += v
+			perShard[sh.Node.Addr] = v
+			if v > m.value(largest) {
+				largest = sh
+			}
+		}
+		top := m.value(largest)
+		if top < m.min {
+			continue
+		}
+		mean := float64(sum) / float64(len(shards))
+		ratio := float64(top) / mean
+		if ratio < threshold {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:           FindingClusterShardImbalance,
+			Severity:     SeverityMedium,
+			ResourceType: "ClusterNode",
+			ResourceID:   largest.Node.Addr,
+			Message: fmt.Sprintf("shard %s holds %s, %.1fx the shard average of %s",
+				largest.Node.Addr, m.format(top), ratio, m.format(int64(mean))),
+			Metadata: map[string]any{
+				"metric":         m.name,
+				"ratio":          ratio,
+				"threshold":      threshold,
+				"per_shard":      perShard,
+				"slots":          slots,
+				"recommendation": m.recommendation,
+			},
+		})
+	}
+	return findings
+}
+
+// hashTags reports hash tags shared by a large share of the keys sampled
+// across the masters, which Redis Cluster places in a single slot and so on
+// a single shard. SCAN only walks one node, so every master is sampled
+// through eachShard and the tag counts are merged; masters that cannot be
+// sampled are recorded as a skipped check.
+func (s *ClusterScanner) hashTags(ctx context.Context, client RedisClient, cfg AuditConfig, shards []clusterShard) ([]Finding, error) {
+	type tagKeys struct {
+		count    int
+		examples []string
+	}
+	tags := make(map[string]*tagKeys)
+	sampled, masters := 0, 0
+	unread, err := s.eachShard(ctx, client, cfg, shards, func(_ *clusterShard, c RedisClient) error {
+		masters++
+		return forEachSampledKey(ctx, c, cfg.SampleSize, func(key string) bool {
+			sampled++
+			tag, ok := hashTag(key)
+			if !ok {
+				return true
+			}
+			t, ok := tags[tag]
+			if !ok {
+				t = &tagKeys{}
+				tags[tag] = t
+			}
+			t.count++
+			if len(t.examples) < clusterListMax {
+				t.examples = append(t.examples, key)
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(unread) > 0 {
+		reason := "could not sample shards " + strings.Join(unread, ", ")
+		if cfg.DialShard == nil {
+			reason = "only the connected node is sampled without a shard dialer"
+		}
+		cfg.skipped.add(SkippedCheck{Auditor: s.Name(), Check: "hash_tags", Reason: reason})
+	}
+
+	names := make([]string, 0, len(tags))
+	for tag, t := range tags {
+		if t.count >= hashTagMinKeys && float64(t.count) >= hashTagShare*float64(sampled) {
+			names = append(names, tag)
+		}
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if tags[names[i]].count != tags[names[j]].count {
+			return tags[names[i]].count > tags[names[j]].count
+		}
+		return names[i] < names[j]
+	})
+
+	findings := make([]Finding, 0, len(names))
+	for _, tag := range names {
+		t := tags[tag]
+		slot := keySlot(tag)
+		shard := slotOwner(shards, slot)
+		share := float64(t.count) / float64(sampled)
+		findings = append(findings, Finding{
+			ID:           FindingClusterHashTagHotspot,
+			Severity:     SeverityMedium,
+			ResourceType: "KeyPattern",
+			ResourceID:   "{" + tag + "}",
+			Message: fmt.Sprintf("%d of %d keys sampled across %d masters (%.0f%%) share hash tag {%s} and are pinned to slot %d on %s",
+				t.count, sampled, masters, share*100, tag, slot, shard),
+			Metadata: map[string]any{
+				"hash_tag":        tag,
+				"slot":            slot,
+				"shard":           shard,
+				"count":           t.count,
+				"sampled":         sampled,
+				"sampled_masters": masters,
+				"masters":         len(shards),
+				"share_percent":   share * 100,
+				"examples":        t.examples,
+				"recommendation":  "use hash tags only for keys that must share a slot for multi-key commands, and make the tag specific (per user or tenant) so keys spread across slots",
+			},
+		})
+	}
+	return findings, nil
+}
+
+// slotOwner returns the address of the master serving slot.
+func slotOwner(shards []clusterShard, slot int) string {
+	for _, sh := range shards {
+		for _, r := range sh.Node.Slots {
+			if slot >= r.Start && slot <= r.End {
+				return sh.Node.Addr
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const clusterNodesRaw = `07c37dfeb235213a872192d90877d0cd55635b91 10.0.0.1:6379@16379 myself,master - 0 1426238317239 1 connected 0-5460 [5461-<-292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f]
+67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 10.0.0.2:6379@16379,redis-2 master - 0 1426238316232 2 connected 5461-10922
+292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 master - 0 1426238318243 3 connected 10923-16383
+6ec23923021cf3ffec47632106199cb7f496ce01 10.0.0.4:6379@16379 slave 67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 0 1426238316232 2 connected
+`
+
+func TestClusterScanner_Name(t *testing.T) {
+	s := &ClusterScanner{}
+	if s.Name() != "cluster" {
+		t.Errorf("expected name 'cluster', got %q", s.Name())
+	}
+}
+
+func TestParseClusterNodes(t *testing.T) {
+	nodes := parseClusterNodes(clusterNodesRaw)
+	if len(nodes) != 4 {
+		t.Fatalf("expected 4 nodes, got %d", len(nodes))
+	}
+	self := nodes[0]
+	if !self.Flags["myself"] || !self.Flags["master"] || self.Addr != "10.0.0.1:6379" {
+		t.Errorf("unexpected first node: %+v", self)
+	}
+	if self.slotCount() != 5461 {
+		t.Errorf("expected 5461 slots, got %d", self.slotCount())
+	}
+	if self.Importing[5461] != "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f" {
+		t.Errorf("expected slot 5461 importing, got %v", self.Importing)
+	}
+	if nodes[1].Addr != "10.0.0.2:6379" {
+		t.Errorf("expected hostname stripped from address, got %s", nodes[1].Addr)
+	}
+	if nodes[3].MasterID != nodes[1].ID {
+		t.Errorf("expected replica of %s, got %s", nodes[1].ID, nodes[3].MasterID)
+	}
+}
+
+func TestKeySlot(t *testing.T) {
+	tests := []struct {
+		key  string
+		slot int
+	}{
+		{"123456789", 12739},
+		{"foo", 12182},
+		{"{user1000}.following", keySlot("user1000")},
+		{"foo{}{bar}", keySlot("foo{}{bar}")},
+	}
+	for _, tt := range tests {
+		if got := keySlot(tt.key); got != tt.slot {
+			t.Errorf("keySlot(%q) = %d, want %d", tt.key, got, tt.slot)
+		}
+	}
+	if _, ok := hashTag("foo{}{bar}"); ok {
+		t.Error("expected an empty first tag to hash the whole key")
+	}
+	if tag, _ := hashTag("{user1000}.followers"); tag != "user1000" {
+		t.Errorf("expected tag user1000, got %q", tag)
+	}
+}
+
+// shardMock returns a cluster node reporting the given metrics.
+func shardMock(keys, usedMemory, ops int64, nodes string) *mockClient {
+	mock := newMockClient()
+	mock.infoResponses["cluster"] = "# Cluster\ncluster_enabled:1\n"
+	mock.infoResponses["memory"] = fmt.Sprintf("# Memory\nused_memory:%d\n", usedMemory)
+	mock.infoResponses["stats"] = fmt.Sprintf("# Stats\ninstantaneous_ops_per_sec:%d\n", ops)
+	mock.infoResponses["keyspace"] = fmt.Sprintf("# Keyspace\ndb0:keys=%d,expires=0,avg_ttl=0\n", keys)
+	mock.clusterInfo = "cluster_state:ok\r\ncluster_slots_assigned:16384\r\ncluster_slots_ok:16384\r\ncluster_slots_fail:0\r\ncluster_known_nodes:4\r\n"
+	mock.clusterNodes = nodes
+	return mock
+}
+
+func clusterDialer(shards map[string]*mockClient) func(context.Context, string) (RedisClient, error) {
+	return func(_ context.Context, addr string) (RedisClient, error) {
+		if c, ok := shards[addr]; ok {
+			return c, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+}
+
+func TestClusterScanner_Standalone(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["cluster"] = "# Cluster\ncluster_enabled:0\n"
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected no findings for a standalone server, got %v", findings)
+	}
+}
+
+func TestClusterScanner_ShardsAndSlots(t *testing.T) {
+	mock := shardMock(1000, 100<<20, 50, clusterNodesRaw)
+	mock.configValues["cluster-require-full-coverage"] = map[string]string{"cluster-require-full-coverage": "yes"}
+	shards := map[string]*mockClient{
+		"10.0.0.2:6379": shardMock(1000, 100<<20, 60, "67ed2db8d677e59ec4a4cefb06858cf2a1a89fa1 10.0.0.2:6379@16379 myself,master - 0 0 2 connected 5461-10922\n"),
+		"10.0.0.3:6379": shardMock(7000, 500<<20, 40, "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 myself,master - 0 0 3 connected 10923-16383 [5461->-07c37dfeb235213a872192d90877d0cd55635b91]\n"),
+	}
+	cfg := AuditConfig{Addr: "10.0.0.1:6379", DialShard: clusterDialer(shards), skipped: &skipList{}}
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+
+	if len(byID[FindingClusterSlotsUncovered]) != 0 {
+		t.Errorf("expected full coverage, got %v", byID[FindingClusterSlotsUncovered])
+	}
+
+	migrating := byID[FindingClusterSlotMigrating]
+	if len(migrating) != 2 {
+		t.Fatalf("expected open slots on both sides of the migration, got %v", migrating)
+	}
+	if got := migrating[0].Metadata["importing"].([]string); len(got) != 1 || got[0] != "5461 10.0.0.3:6379" {
+		t.Errorf("expected slot 5461 importing from 10.0.0.3, got %v", got)
+	}
+
+	risk := byID[FindingClusterCoverageRisk]
+	if len(risk) != 1 || len(risk[0].Metadata["masters_without_replicas"].([]string)) != 2 {
+		t.Errorf("expected two masters without replicas, got %v", risk)
+	}
+
+	imbalance := byID[FindingClusterShardImbalance]
+	if len(imbalance) != 2 {
+		t.Fatalf("expected keys and memory imbalance, got %v", imbalance)
+	}
+	for _, f := range imbalance {
+		if f.ResourceID != "10.0.0.3:6379" {
+			t.Errorf("expected 10.0.0.3 as the largest shard, got %s", f.ResourceID)
+		}
+		if f.Metadata["metric"] == "ops_per_sec" {
+			t.Error("expected ops below the minimum to be ignored")
+		}
+	}
+	if len(cfg.skipped.checks) != 0 {
+		t.Errorf("expected no skipped checks, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestClusterScanner_NoDialer(t *testing.T) {
+	mock := shardMock(100000, 1<<30, 0, clusterNodesRaw)
+	cfg := AuditConfig{skipped: &skipList{}}
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	if len(byID[FindingClusterShardImbalance]) != 0 {
+		t.Errorf("expected no imbalance from a single shard's metrics, got %v", byID[FindingClusterShardImbalance])
+	}
+	if len(byID[FindingClusterSlotMigrating]) != 1 {
+		t.Errorf("expected the connected node's open slot, got %v", byID[FindingClusterSlotMigrating])
+	}
+	if len(byID[FindingClusterCoverageRisk]) != 0 {
+		t.Errorf("expected no coverage risk without the setting, got %v", byID[FindingClusterCoverageRisk])
+	}
+	if len(cfg.skipped.checks) != 2 || cfg.skipped.checks[0].Check != "shard_balance" || cfg.skipped.checks[1].Check != "hash_tags" {
+		t.Errorf("expected shard_balance and hash_tags to be skipped, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestClusterScanner_Uncovered(t *testing.T) {
+	nodes := "07c37dfeb235213a872192d90877d0cd55635b91 10.0.0.1:6379@16379 myself,master - 0 0 1 connected 0-5460\n" +
+		"292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 master - 0 0 3 connected 10923-16383\n"
+	mock := shardMock(0, 0, 0, nodes)
+	mock.clusterInfo = "cluster_state:fail\r\ncluster_slots_assigned:10922\r\ncluster_slots_fail:0\r\n"
+	mock.configValues["cluster-require-full-coverage"] = map[string]string{"cluster-require-full-coverage": "no"}
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uncovered := findingsByID(findings)[FindingClusterSlotsUncovered]
+	if len(uncovered) != 1 {
+		t.Fatalf("expected CLUSTER_SLOTS_UNCOVERED, got %v", findings)
+	}
+	if uncovered[0].Severity != SeverityHigh {
+		t.Errorf("expected high severity without full coverage required, got %s", uncovered[0].Severity)
+	}
+	if got := uncovered[0].Metadata["unassigned_ranges"].([]string); len(got) != 1 || got[0] != "5461-10922" {
+		t.Errorf("expected unassigned range 5461-10922, got %v", got)
+	}
+}
+
+func TestClusterScanner_HashTagHotspot(t *testing.T) {
+	// {shop} hashes to slot 3808, served by the master that is not connected.
+	nodes := "07c37dfeb235213a872192d90877d0cd55635b91 10.0.0.1:6379@16379 myself,master - 0 0 1 connected 8192-16383\n" +
+		"292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 master - 0 0 3 connected 0-8191\n"
+	mock := shardMock(0, 0, 0, nodes)
+	for i := 0; i < 150; i++ {
+		mock.scanKeys = append(mock.scanKeys, fmt.Sprintf("session:%d", i))
+	}
+	other := shardMock(0, 0, 0, "292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 myself,master - 0 0 3 connected 0-8191\n")
+	for i := 0; i < 150; i++ {
+		other.scanKeys = append(other.scanKeys, fmt.Sprintf("cart:{shop}:%d", i))
+	}
+	cfg := AuditConfig{Addr: "10.0.0.1:6379", DialShard: clusterDialer(map[string]*mockClient{"10.0.0.3:6379": other}), skipped: &skipList{}}
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hotspots := findingsByID(findings)[FindingClusterHashTagHotspot]
+	if len(hotspots) != 1 {
+		t.Fatalf("expected one hash tag hotspot, got %v", findings)
+	}
+	f := hotspots[0]
+	if f.ResourceID != "{shop}" || f.Metadata["count"] != 150 || f.Metadata["slot"] != keySlot("shop") {
+		t.Errorf("unexpected hotspot: %s %v", f.ResourceID, f.Metadata)
+	}
+	if f.Metadata["shard"] != "10.0.0.3:6379" || f.Metadata["sampled"] != 300 || f.Metadata["sampled_masters"] != 2 {
+		t.Errorf("expected the hotspot on 10.0.0.3 out of both masters' samples, got %v", f.Metadata)
+	}
+	if len(cfg.skipped.checks) != 0 {
+		t.Errorf("expected no skipped checks, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestClusterScanner_HashTagsWithoutDialer(t *testing.T) {
+	nodes := "07c37dfeb235213a872192d90877d0cd55635b91 10.0.0.1:6379@16379 myself,master - 0 0 1 connected 0-8191\n" +
+		"292f8b365bb7edb5e285caf0b7e6ddc7265d2f4f 10.0.0.3:6379@16379 master - 0 0 3 connected 8192-16383\n"
+	mock := shardMock(0, 0, 0, nodes)
+	for i := 0; i < 150; i++ {
+		mock.scanKeys = append(mock.scanKeys, fmt.Sprintf("cart:{shop}:%d", i))
+	}
+	cfg := AuditConfig{skipped: &skipList{}}
+
+	findings, err := (&ClusterScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hotspots := findingsByID(findings)[FindingClusterHashTagHotspot]
+	if len(hotspots) != 1 || hotspots[0].Metadata["sampled_masters"] != 1 {
+		t.Fatalf("expected a hotspot from the connected node's sample, got %v", findings)
+	}
+	var checks []string
+	for _, c := range cfg.skipped.checks {
+		checks = append(checks, c.Check)
+	}
+	if strings.Join(checks, ",") != "shard_balance,hash_tags" {
+		t.Errorf("expected shard_balance and hash_tags to be skipped, got %v", checks)
+	}
+}
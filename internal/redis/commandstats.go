@@ -0,0 +1,368 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// hotPathShare is the share of all calls at which a command is on a hot path.
+	hotPathShare = 0.05
+	// slowCallUsec and slowCallHighUsec are average call durations reported
+	// as medium and high; slowCallMinCalls skips rarely used commands.
+	slowCallUsec     = 1000
+	slowCallHighUsec = 10000
+	slowCallMinCalls = 100
+	// failureRatio is the failed plus rejected share of calls reported as a
+	// failing command; failureMinCalls skips commands that failed a handful of times.
+	failureRatio    = 0.05
+	failureMinCalls = 100
+	// dominantErrorMin is the error reply count below which error types are
+	// not reported.
+	dominantErrorMin = 100
+	// commandActivePerHour is the average hourly count over uptime below
+	// which a counter is treated as history rather than current behavior.
+	commandActivePerHour = 1
+	// commandTableSize is the number of commands kept in the inventory.
+	commandTableSize = 20
+)
+
+// collectionAlternatives suggests incremental replacements for commands that
+// return a whole collection.
+var collectionAlternatives = map[string]string{
+	"smembers": "SSCAN or SISMEMBER",
+	"hgetall":  "HSCAN, or HMGET for the fields needed",
+}
+
+// CommandStat is one command's cumulative counters from INFO commandstats.
+type CommandStat struct {
+	Command       string  `json:"command"`
+	Calls         int64   `json:"calls"`
+	Usec          int64   `json:"usec"`
+	UsecPerCall   float64 `json:"usec_per_call"`
+	RejectedCalls int64   `json:"rejected_calls"`
+	FailedCalls   int64   `json:"failed_calls"`
+	CallsShare    float64 `json:"calls_share"`
+}
+
+// ErrorStat is one error type's count from INFO errorstats.
+type ErrorStat struct {
+	Type  string  `json:"type"`
+	Count int64   `json:"count"`
+	Share float64 `json:"share"`
+}
+
+// ParseCommandStats parses INFO commandstats ("cmdstat_get:calls=10,...")
+// into per-command counters sorted by total time, largest first. Subcommands
+// keep their "config|get" form.
+func ParseCommandStats(raw string) []CommandStat {
+	var stats []CommandStat
+	var total int64
+	for name, value := range ParseInfo(raw) {
+		cmd, ok := strings.CutPrefix(name, "cmdstat_")
+		if !ok {
+			continue
+		}
+		fields := ParseInfoFields(value)
+		st := CommandStat{
+			Command:       cmd,
+			Calls:         infoInt(fields, "calls"),
+			Usec:          infoInt(fields, "usec"),
+			UsecPerCall:   infoFloat(fields, "usec_per_call"),
+			RejectedCalls: infoInt(fields, "rejected_calls"),
+			FailedCalls:   infoInt(fields, "failed_calls"),
+		}
+		total += st.Calls
+		stats = append(stats, st)
+	}
+	for i := range stats {
+		if total > 0 {
+			stats[i].CallsShare = float64(stats[i].Calls) / float64(total)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Usec != stats[j].Usec {
+			return stats[i].Usec > stats[j].Usec
+		}
+		return stats[i].Command < stats[j].Command
+	})
+	return stats
+}
+
+// ParseErrorStats parses INFO errorstats ("errorstat_ERR:count=5") sorted by
+// count, largest first.
+func ParseErrorStats(raw string) []ErrorStat {
+	var stats []ErrorStat
+	var total int64
+	for name, value := range ParseInfo(raw) {
+		typ, ok := strings.CutPrefix(name, "errorstat_")
+		if !ok {
+			continue
+		}
+		st := ErrorStat{Type: typ, Count: infoInt(ParseInfoFields(value), "count")}
+		total += st.Count
+		stats = append(stats, st)
+	}
+	for i := range stats {
+		if total > 0 {
+			stats[i].Share = float64(stats[i].Count) / float64(total)
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Type < stats[j].Type
+	})
+	return stats
+}
+
+// CommandStatsScanner audits INFO commandstats and errorstats for command
+// anti-patterns, slow command types, and failing calls. Findings judge the
+// counters' increase since the last audit, or the lifetime totals averaged
+// over uptime, so a command run once months ago is not reported.
+type CommandStatsScanner struct{}
+
+func (s *CommandStatsScanner) Name() string { return "commandstats" }
+
+func (s *CommandStatsScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.Info(ctx, "commandstats")
+	if err != nil {
+		return nil, fmt.Errorf("info commandstats: %w", err)
+	}
+	commands := ParseCommandStats(raw)
+
+	// errorstats exists from Redis 7; older servers return an empty section.
+	errRaw, err := client.Info(ctx, "errorstats")
+	if err != nil {
+		return nil, fmt.Errorf("info errorstats: %w", err)
+	}
+	errStats := ParseErrorStats(errRaw)
+
+	serverRaw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	counters := countersFor(cfg, ParseInfo(serverRaw), ParseInfo(raw), ParseInfo(errRaw))
+
+	table := commands
+	if len(table) > commandTableSize {
+		table = table[:commandTableSize]
+	}
+	cfg.inventory.set("commandstats", map[string]any{
+		"commands":   table,
+		"errorstats": errStats,
+	})
+
+	windowed, windows := commandRates(counters, commands)
+
+	var findings []Finding
+	for i, c := range windowed {
+		if f, ok := s.antipattern(cfg, c, windows[i]); ok {
+			findings = append(findings, f)
+		}
+		if f, ok := s.slowCalls(cfg, c, windows[i]); ok {
+			findings = append(findings, f)
+		}
+		if f, ok := s.failures(cfg, c, windows[i]); ok {
+			findings = append(findings, f)
+		}
+	}
+	errWindowed, errRate := errorRates(counters, errStats)
+	if f, ok := s.dominantErrors(cfg, errWindowed, errRate); ok {
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// commandRates returns each command's counters over its own window, and
+// that window. A command missing from the previous snapshot falls back to a
+// since-restart window while the others cover the time since the last audit,
+// so calls shares are computed from calls per hour to compare them.
+func commandRates(counters *Counters, commands []CommandStat) ([]CommandStat, []Rate) {
+	var total float64
+	out := make([]CommandStat, 0, len(commands))
+	windows := make([]Rate, 0, len(commands))
+	for _, c := range commands {
+		calls := counters.Rate(commandCounter(c.Command, "calls"))
+		w := CommandStat{
+			Command:       c.Command,
+			Calls:         calls.Count,
+			Usec:          counters.Rate(commandCounter(c.Command, "usec")).Count,
+			FailedCalls:   counters.Rate(commandCounter(c.Command, "failed_calls")).Count,
+			RejectedCalls: counters.Rate(commandCounter(c.Command, "rejected_calls")).Count,
+		}
+		if w.Calls > 0 {
+			w.UsecPerCall = float64(w.Usec) / float64(w.Calls)
+		}
+		total += callWeight(calls)
+		out = append(out, w)
+		windows = append(windows, Rate{Window: calls.Window, Source: calls.Source})
+	}
+	for i := range out {
+		if total > 0 {
+			out[i].CallsShare = callWeight(Rate{Count: out[i].Calls, Window: windows[i].Window}) / total
+		}
+	}
+	return out, windows
+}
+
+// callWeight is a command's calls per hour, or its count when the counters
+// have no window.
+func callWeight(r Rate) float64 {
+	if r.Window > 0 {
+		return r.PerHour()
+	}
+	return float64(r.Count)
+}
+
+// errorRates returns error type counts over the counters' window, with
+// shares recomputed for that window, and the window as a Rate of the total.
+func errorRates(counters *Counters, errStats []ErrorStat) ([]ErrorStat, Rate) {
+	var total Rate
+	out := make([]ErrorStat, 0, len(errStats))
+	for _, e := range errStats {
+		r := counters.Rate(errorCounter(e.Type))
+		total.Count += r.Count
+		total.Window, total.Source = r.Window, r.Source
+		if r.Count > 0 {
+			out = append(out, ErrorStat{Type: e.Type, Count: r.Count})
+		}
+	}
+	for i := range out {
+		out[i].Share = float64(out[i].Count) / float64(total.Count)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Type < out[j].Type
+	})
+	return out, total
+}
+
+// commandActive reports whether count over the window is current behavior.
+func commandActive(count int64, window Rate) bool {
+	window.Count = count
+	return window.Active(commandActivePerHour)
+}
+
+func (s *CommandStatsScanner) antipattern(cfg AuditConfig, c CommandStat, window Rate) (Finding, bool) {
+	if !commandActive(c.Calls, window) {
+		return Finding{}, false
+	}
+	var severity Severity
+	var reason string
+	switch c.Command {
+	case "keys":
+		severity = SeverityHigh
+		reason = "KEYS walks the whole keyspace and blocks the server; use SCAN"
+	case "flushall", "flushdb":
+		severity = SeverityMedium
+		reason = strings.ToUpper(c.Command) + " deletes data in bulk; rename or deny it for application users"
+	case "smembers", "hgetall":
+		if c.CallsShare < hotPathShare {
+			return Finding{}, false
+		}
+		severity = SeverityLow
+		if c.UsecPerCall >= slowCallUsec {
+			severity = SeverityMedium
+		}
+		reason = fmt.Sprintf("%s returns the whole collection on a hot path (%.1f%% of calls); use %s",
+			strings.ToUpper(c.Command), c.CallsShare*100, collectionAlternatives[c.Command])
+	default:
+		return Finding{}, false
+	}
+	return Finding{
+		ID:           FindingCommandAntipattern,
+		Severity:     severity,
+		ResourceType: "Command",
+		ResourceID:   cfg.Addr + "/" + c.Command,
+		Message:      fmt.Sprintf("%s called %d times %s: %s", strings.ToUpper(c.Command), c.Calls, window.Describe(), reason),
+		Metadata:     commandMetadata(c, window),
+	}, true
+}
+
+func (s *CommandStatsScanner) slowCalls(cfg AuditConfig, c CommandStat, window Rate) (Finding, bool) {
+	if c.Calls < slowCallMinCalls || c.UsecPerCall < slowCallUsec || !commandActive(c.Calls, window) {
+		return Finding{}, false
+	}
+	severity := SeverityMedium
+	if c.UsecPerCall >= slowCallHighUsec {
+		severity = SeverityHigh
+	}
+	return Finding{
+		ID:           FindingHighCommandLatency,
+		Severity:     severity,
+		ResourceType: "Command",
+		ResourceID:   cfg.Addr + "/" + c.Command,
+		Message: fmt.Sprintf("%s averages %.2f ms per call over %d calls %s",
+			strings.ToUpper(c.Command), c.UsecPerCall/1000, c.Calls, window.Describe()),
+		Metadata: commandMetadata(c, window),
+	}, true
+}
+
+func (s *CommandStatsScanner) failures(cfg AuditConfig, c CommandStat, window Rate) (Finding, bool) {
+	bad := c.FailedCalls + c.RejectedCalls
+	attempts := c.Calls + c.RejectedCalls
+	if bad < failureMinCalls || attempts == 0 || !commandActive(bad, window) {
+		return Finding{}, false
+	}
+	ratio := float64(bad) / float64(attempts)
+	if ratio < failureRatio {
+		return Finding{}, false
+	}
+	meta := commandMetadata(c, window)
+	meta["failure_ratio"] = ratio
+	return Finding{
+		ID:           FindingCommandFailures,
+		Severity:     SeverityMedium,
+		ResourceType: "Command",
+		ResourceID:   cfg.Addr + "/" + c.Command,
+		Message: fmt.Sprintf("%.1f%% of %s calls fail or are rejected %s (%d failed, %d rejected)",
+			ratio*100, strings.ToUpper(c.Command), window.Describe(), c.FailedCalls, c.RejectedCalls),
+		Metadata: meta,
+	}, true
+}
+
+func (s *CommandStatsScanner) dominantErrors(cfg AuditConfig, errStats []ErrorStat, window Rate) (Finding, bool) {
+	total := window.Count
+	if total < dominantErrorMin || !window.Active(commandActivePerHour) {
+		return Finding{}, false
+	}
+	top := errStats
+	if len(top) > 3 {
+		top = top[:3]
+	}
+	parts := make([]string, len(top))
+	for i, e := range top {
+		parts[i] = fmt.Sprintf("%s %.0f%%", e.Type, e.Share*100)
+	}
+	return Finding{
+		ID:           FindingDominantErrors,
+		Severity:     SeverityLow,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message:      fmt.Sprintf("%d error replies %s, mostly %s", total, window.Describe(), strings.Join(parts, ", ")),
+		Metadata: map[string]any{
+			"total_errors":   total,
+			"top_errors":     top,
+			"counter_window": window.Source,
+		},
+	}, true
+}
+
+func commandMetadata(c CommandStat, window Rate) map[string]any {
+	return map[string]any{
+		"counter_window": window.Source,
+		"command":        c.Command,
+		"calls":          c.Calls,
+		"usec":           c.Usec,
+		"usec_per_call":  c.UsecPerCall,
+		"failed_calls":   c.FailedCalls,
+		"rejected_calls": c.RejectedCalls,
+		"calls_share":    c.CallsShare,
+	}
+}
@@ -0,0 +1,209 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+const commandStatsFixture = "# Commandstats\r\n" +
+	"cmdstat_get:calls=900000,usec=900000,usec_per_call=1.00,rejected_calls=0,failed_calls=0\r\n" +
+	"cmdstat_hgetall:calls=100000,usec=50000000,usec_per_call=500.00,rejected_calls=0,failed_calls=0\r\n" +
+	"cmdstat_keys:calls=3,usec=90000,usec_per_call=30000.00,rejected_calls=0,failed_calls=0\r\n" +
+	"cmdstat_flushdb:calls=1,usec=10,usec_per_call=10.00,rejected_calls=0,failed_calls=0\r\n" +
+	"cmdstat_zrangebyscore:calls=2000,usec=30000000,usec_per_call=15000.00,rejected_calls=0,failed_calls=0\r\n" +
+	"cmdstat_eval:calls=1000,usec=100000,usec_per_call=100.00,rejected_calls=50,failed_calls=200\r\n" +
+	"cmdstat_config|get:calls=5,usec=50,usec_per_call=10.00,rejected_calls=0,failed_calls=0\r\n"
+
+func TestParseCommandStats(t *testing.T) {
+	stats := ParseCommandStats(commandStatsFixture)
+	if len(stats) != 7 {
+		t.Fatalf("expected 7 commands, got %d", len(stats))
+	}
+	if stats[0].Command != "hgetall" || stats[0].Usec != 50000000 {
+		t.Errorf("expected hgetall first by total time, got %+v", stats[0])
+	}
+	for _, s := range stats {
+		if s.Command == "eval" && (s.FailedCalls != 200 || s.RejectedCalls != 50) {
+			t.Errorf("unexpected eval counters: %+v", s)
+		}
+		if s.Command == "get" && (s.CallsShare < 0.89 || s.CallsShare > 0.90) {
+			t.Errorf("unexpected get share %f", s.CallsShare)
+		}
+	}
+}
+
+func TestParseErrorStats(t *testing.T) {
+	stats := ParseErrorStats("# Errorstats\r\nerrorstat_ERR:count=30\r\nerrorstat_WRONGTYPE:count=70\r\n")
+	if len(stats) != 2 || stats[0].Type != "WRONGTYPE" || stats[0].Share != 0.7 {
+		t.Errorf("unexpected error stats: %+v", stats)
+	}
+}
+
+func TestCommandStatsScanner_Name(t *testing.T) {
+	s := &CommandStatsScanner{}
+	if s.Name() != "commandstats" {
+		t.Errorf("expected name 'commandstats', got %q", s.Name())
+	}
+}
+
+func TestCommandStatsScanner_Findings(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["commandstats"] = commandStatsFixture
+	mock.infoResponses["errorstats"] = "errorstat_ERR:count=200\r\nerrorstat_NOSCRIPT:count=50\r\n"
+	cfg := AuditConfig{Addr: "localhost:6379", inventory: &inventory{data: make(map[string]any)}}
+
+	s := &CommandStatsScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := make(map[string]Finding)
+	for _, f := range findings {
+		got[string(f.ID)+" "+f.ResourceID] = f
+	}
+	want := map[string]Severity{
+		"COMMAND_ANTIPATTERN localhost:6379/keys":           SeverityHigh,
+		"COMMAND_ANTIPATTERN localhost:6379/flushdb":        SeverityMedium,
+		"COMMAND_ANTIPATTERN localhost:6379/hgetall":        SeverityLow,
+		"HIGH_COMMAND_LATENCY localhost:6379/zrangebyscore": SeverityHigh,
+		"COMMAND_FAILURES localhost:6379/eval":              SeverityMedium,
+		"DOMINANT_ERROR_TYPES localhost:6379":               SeverityLow,
+	}
+	for key, sev := range want {
+		f, ok := got[key]
+		if !ok {
+			t.Errorf("expected %s, got %+v", key, findings)
+			continue
+		}
+		if f.Severity != sev {
+			t.Errorf("%s: expected severity %q, got %q", key, sev, f.Severity)
+		}
+	}
+	if len(findings) != len(want) {
+		t.Errorf("expected %d findings, got %d", len(want), len(findings))
+	}
+
+	inv, ok := cfg.inventory.data["commandstats"].(map[string]any)
+	if !ok {
+		t.Fatal("expected commandstats inventory")
+	}
+	if table := inv["commands"].([]CommandStat); len(table) != 7 || table[0].Command != "hgetall" {
+		t.Errorf("unexpected command table: %+v", table)
+	}
+}
+
+func TestCommandStatsScanner_StaleCounters(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["commandstats"] = commandStatsFixture
+	// Three KEYS calls over 90 days of uptime are history, not current use.
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:7776000\n"
+
+	findings, err := (&CommandStatsScanner{}).Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, f := range findings {
+		if f.ResourceID == "localhost:6379/keys" || f.ResourceID == "localhost:6379/flushdb" {
+			t.Errorf("expected rare lifetime calls to be ignored, got %s %s", f.ID, f.ResourceID)
+		}
+	}
+	if len(findingsByID(findings)[FindingCommandAntipattern]) != 1 {
+		t.Errorf("expected only the hot-path HGETALL antipattern, got %+v", findings)
+	}
+}
+
+func TestCommandStatsScanner_SinceLastAudit(t *testing.T) {
+	now := time.Now()
+	info := ParseInfo("run_id:abc\r\nuptime_in_seconds:7776000\r\n" + commandStatsFixture)
+	previous := NewSnapshot(info, now.Add(-time.Hour))
+	previous.Uptime -= 3600
+	previous.Counters[commandCounter("keys", "calls")] = 1
+	previous.Counters[commandCounter("hgetall", "calls")] = 100000
+
+	mock := newMockClient()
+	mock.infoResponses["commandstats"] = commandStatsFixture
+	cfg := AuditConfig{Addr: "localhost:6379", counters: NewCounters(NewSnapshot(info, now), &previous)}
+
+	findings, err := (&CommandStatsScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]Finding)
+	for _, f := range findings {
+		got[f.ResourceID] = f
+	}
+	keys, ok := got["localhost:6379/keys"]
+	if !ok || keys.Metadata["calls"] != int64(2) || keys.Metadata["counter_window"] != RateSinceLastAudit {
+		t.Errorf("expected KEYS called twice since the last audit, got %+v", keys)
+	}
+	if _, ok := got["localhost:6379/hgetall"]; ok {
+		t.Error("expected HGETALL with no calls since the last audit to be ignored")
+	}
+}
+
+func TestCommandStatsScanner_MixedWindows(t *testing.T) {
+	now := time.Now()
+	info := ParseInfo("run_id:abc\r\nuptime_in_seconds:7776000\r\n" + commandStatsFixture)
+	previous := NewSnapshot(info, now.Add(-time.Hour))
+	previous.Uptime -= 3600
+	previous.Counters[commandCounter("keys", "calls")] = 1
+	previous.Counters[commandCounter("get", "calls")] = 900000 - 3600
+	// Commands first called after the previous audit fall back to the
+	// since-restart window; FLUSHDB sorts last.
+	for _, cmd := range []string{"zrangebyscore", "eval", "config|get", "flushdb"} {
+		for _, field := range commandCounterFields {
+			delete(previous.Counters, commandCounter(cmd, field))
+		}
+	}
+
+	mock := newMockClient()
+	mock.infoResponses["commandstats"] = commandStatsFixture
+	cfg := AuditConfig{Addr: "localhost:6379", counters: NewCounters(NewSnapshot(info, now), &previous)}
+
+	findings, err := (&CommandStatsScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]Finding)
+	for _, f := range findings {
+		got[f.ResourceID] = f
+	}
+	keys, ok := got["localhost:6379/keys"]
+	if !ok || keys.Metadata["counter_window"] != RateSinceLastAudit {
+		t.Fatalf("expected KEYS judged over its own since-last-audit window, got %+v", keys)
+	}
+	if !strings.Contains(keys.Message, "since the last audit") {
+		t.Errorf("expected the message to describe the last-audit window, got %q", keys.Message)
+	}
+	zrange, ok := got["localhost:6379/zrangebyscore"]
+	if ok && zrange.Metadata["counter_window"] != RateSinceRestart {
+		t.Errorf("expected ZRANGEBYSCORE judged since the restart, got %+v", zrange)
+	}
+	if _, ok := got["localhost:6379/flushdb"]; ok {
+		t.Error("expected one FLUSHDB over 90 days of uptime to be ignored")
+	}
+
+	windowed, _ := commandRates(cfg.counters, ParseCommandStats(commandStatsFixture))
+	for _, c := range windowed {
+		if c.Command == "get" && c.CallsShare < 0.99 {
+			t.Errorf("expected GET's share compared per hour across windows, got %.3f", c.CallsShare)
+		}
+	}
+}
+
+func TestCommandStatsScanner_Quiet(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["commandstats"] = "cmdstat_get:calls=1000,usec=1000,usec_per_call=1.00\r\ncmdstat_smembers:calls=10,usec=100,usec_per_call=10.00\r\n"
+
+	s := &CommandStatsScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %+v", findings)
+	}
+}
@@ -0,0 +1,259 @@
+package redis
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/lzw"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+)
+
+const (
+	// maxCompressSample bounds how much of a single value is compressed;
+	// the measured ratio is extrapolated to the rest of the value.
+	maxCompressSample = 256 * 1024
+	// minFieldCompressSize skips hash field values too small to be worth
+	// compressing individually.
+	minFieldCompressSize = 128
+	maxHashFieldsSampled = 1000
+	// maxHashFieldsScanned bounds the HSCAN of one hash by fields seen, so a
+	// hash of many small fields is not walked to the end.
+	maxHashFieldsScanned = 10000
+	minCompressSavings   = 0.3
+)
+
+// compressionCodecs are measured against every sampled value.
+var compressionCodecs = []string{"gzip", "lzw"}
+
+// CompressibilityScanner estimates how much memory client-side compression
+// would save per namespace. Values are compressed in memory only and never
+// retained or reported.
+type CompressibilityScanner struct{}
+
+func (s *CompressibilityScanner) Name() string { return "compression" }
+
+type compressionStats struct {
+	keys       int
+	values     int
+	rawBytes   int64
+	compressed map[string]int64
+}
+
+func (s *CompressibilityScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	minSize := largeValueSize(cfg)
+	budget := newValueBudget(cfg.ValueBudget)
+	stats := make(map[string]*compressionStats)
+	walked := 0
+	exhausted := false
+
+	record := func(ns string, value []byte, fullLength int64) {
+		st, ok := stats[ns]
+		if !ok {
+			st = &compressionStats{compressed: make(map[string]int64)}
+			stats[ns] = st
+		}
+		st.values++
+		st.rawBytes += fullLength
+		for _, codec := range compressionCodecs {
+			size := compressedSize(codec, value)
+			// Extrapolate the ratio measured on the sampled prefix.
+			st.compressed[codec] += int64(float64(size) / float64(len(value)) * float64(fullLength))
+		}
+	}
+
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		walked++
+		keyType, err := client.Type(ctx, key)
+		if err != nil {
+			return true
+		}
+
+		ns := keyNamespace(key)
+		switch keyType {
+		case "string":
+			length, err := client.StrLen(ctx, key)
+			if err != nil || length < minSize {
+				return true
+			}
+			n := min(length, maxCompressSample)
+			if !budget.take(n) {
+				exhausted = true
+				return false
+			}
+			var buf bytes.Buffer
+			if err := streamStringValue(ctx, client, key, n, &buf); err != nil || buf.Len() == 0 {
+				return true
+			}
+			record(ns, buf.Bytes(), length)
+			stats[ns].keys++
+
+		case "hash":
+			mem, err := client.MemoryUsage(ctx, key)
+			if err != nil || mem < minSize {
+				return true
+			}
+			var values [][]byte
+			scanned, ok := s.sampleHash(ctx, client, key, budget, func(value []byte) {
+				values = append(values, value)
+			})
+			// Scale the sampled fields up to the whole hash when HSCAN
+			// stopped short.
+			factor := 1.0
+			if hlen, err := client.HLen(ctx, key); err == nil && scanned > 0 && hlen > int64(scanned) {
+				factor = float64(hlen) / float64(scanned)
+			}
+			for _, value := range values {
+				record(ns, value, int64(float64(len(value))*factor))
+			}
+			if len(values) > 0 {
+				stats[ns].keys++
+			}
+			if !ok {
+				exhausted = true
+				return false
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exhausted {
+		slog.Debug("Value budget exhausted, compression estimate used a partial sample", "auditor", s.Name())
+	}
+
+	// Scale sampled savings up to the whole keyspace when SCAN stopped short.
+	scale := 1.0
+	if dbSize, err := client.DBSize(ctx); err == nil && walked > 0 && dbSize > int64(walked) {
+		scale = float64(dbSize) / float64(walked)
+	}
+
+	var findings []Finding
+	for ns, st := range stats {
+		if f, ok := s.namespaceFinding(ns, st, scale, exhausted); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		si := findings[i].Metadata["estimated_savings_bytes"].(int64)
+		sj := findings[j].Metadata["estimated_savings_bytes"].(int64)
+		if si != sj {
+			return si > sj
+		}
+		return findings[i].ResourceID < findings[j].ResourceID
+	})
+
+	return findings, nil
+}
+
+// sampleHash feeds up to maxHashFieldsSampled field values of a hash to fn,
+// reading at most maxHashFieldsScanned fields of any size. It returns the
+// number of fields read, sampled or not, and false if the budget ran out.
+func (s *CompressibilityScanner) sampleHash(ctx context.Context, client RedisClient, key string, budget *valueBudget, fn func([]byte)) (int, bool) {
+	sampled, scanned := 0, 0
+	var cursor uint64
+	for sampled < maxHashFieldsSampled && scanned < maxHashFieldsScanned {
+		pairs, next, err := client.HScan(ctx, key, cursor, "*", scanBatchSize)
+		if err != nil {
+			return scanned, true
+		}
+		for i := 1; i < len(pairs); i += 2 {
+			scanned++
+			value := pairs[i]
+			if len(value) < minFieldCompressSize {
+				continue
+			}
+			if len(value) > maxCompressSample {
+				value = value[:maxCompressSample]
+			}
+			if !budget.take(int64(len(value))) {
+				return scanned, false
+			}
+			fn([]byte(value))
+			sampled++
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	return scanned, true
+}
+
+func (s *CompressibilityScanner) namespaceFinding(ns string, st *compressionStats, scale float64, partial bool) (Finding, bool) {
+	if st.values == 0 || st.rawBytes == 0 {
+		return Finding{}, false
+	}
+
+	bestCodec := ""
+	bestSize := st.rawBytes
+	ratios := make(map[string]float64, len(st.compressed))
+	for _, codec := range compressionCodecs {
+		size := st.compressed[codec]
+		ratios[codec] = float64(size) / float64(st.rawBytes)
+		if size < bestSize {
+			bestCodec, bestSize = codec, size
+		}
+	}
+
+	sampledSavings := st.rawBytes - bestSize
+	savingsRatio := float64(sampledSavings) / float64(st.rawBytes)
+	if bestCodec == "" || savingsRatio < minCompressSavings {
+		return Finding{}, false
+	}
+	estimated := int64(float64(sampledSavings) * scale)
+
+	severity := SeverityLow
+	if estimated >= defaultBigKeySize {
+		severity = SeverityMedium
+	}
+
+	return Finding{
+		ID:           FindingCompressibleValues,
+		Severity:     severity,
+		ResourceType: "Namespace",
+		ResourceID:   ns,
+		Message: fmt.Sprintf("namespace %q values compress %.0f%% with %s; client-side compression would save an estimated %s",
+			ns, savingsRatio*100, bestCodec, FormatBytes(estimated)),
+		Metadata: map[string]any{
+			"namespace":               ns,
+			"keys_sampled":            st.keys,
+			"values_sampled":          st.values,
+			"sampled_bytes":           st.rawBytes,
+			"best_codec":              bestCodec,
+			"compression_ratios":      ratios,
+			"sampled_savings_bytes":   sampledSavings,
+			"estimated_savings_bytes": estimated,
+			"estimated_savings_human": FormatBytes(estimated),
+			"partial_sample":          partial,
+		},
+	}, true
+}
+
+// compressedSize returns the size of value after compression with codec.
+func compressedSize(codec string, value []byte) int {
+	var counter byteCounter
+	var w io.WriteCloser
+	switch codec {
+	case "gzip":
+		w = gzip.NewWriter(&counter)
+	case "lzw":
+		w = lzw.NewWriter(&counter, lzw.LSB, 8)
+	default:
+		return len(value)
+	}
+	_, _ = w.Write(value)
+	_ = w.Close()
+	return counter.n
+}
+
+type byteCounter struct{ n int }
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += len(p)
+	return len(p), nil
+}
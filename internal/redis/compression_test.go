@@ -0,0 +1,168 @@
+package redis
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCompressibilityScanner_Name(t *testing.T) {
+	s := &CompressibilityScanner{}
+	if s.Name() != "compression" {
+		t.Errorf("expected name 'compression', got %q", s.Name())
+	}
+}
+
+func TestCompressibilityScanner_CompressibleJSON(t *testing.T) {
+	doc := strings.Repeat(`{"user_id":12345,"status":"active","roles":["reader","writer"]},`, 200)
+	fields := make(map[string]string)
+	for i := 0; i < 10; i++ {
+		fields[fmt.Sprintf("f%d", i)] = strings.Repeat(`{"event":"login","ok":true}`, 20)
+	}
+
+	mock := newMockClient()
+	mock.scanKeys = []string{"profile:1", "profile:2", "events:1"}
+	mock.values = map[string]string{"profile:1": doc, "profile:2": doc}
+	mock.hashes = map[string]map[string]string{"events:1": fields}
+	mock.memoryUsages = map[string]int64{"events:1": 8000}
+
+	s := &CompressibilityScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{LargeValueSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 namespace findings, got %d", len(findings))
+	}
+
+	byNS := make(map[string]Finding)
+	for _, f := range findings {
+		if f.ID != FindingCompressibleValues {
+			t.Errorf("expected finding ID %q, got %q", FindingCompressibleValues, f.ID)
+		}
+		if strings.Contains(f.Message, "user_id") || strings.Contains(f.Message, "login") {
+			t.Error("finding message must not contain values")
+		}
+		byNS[f.ResourceID] = f
+	}
+
+	profile, ok := byNS["profile"]
+	if !ok {
+		t.Fatal("expected finding for namespace 'profile'")
+	}
+	if profile.Metadata["keys_sampled"] != 2 {
+		t.Errorf("expected 2 keys sampled, got %v", profile.Metadata["keys_sampled"])
+	}
+	if profile.Metadata["estimated_savings_bytes"].(int64) <= 0 {
+		t.Error("expected positive estimated savings")
+	}
+	if events, ok := byNS["events"]; !ok || events.Metadata["values_sampled"] != 10 {
+		t.Errorf("expected 10 hash field values sampled for 'events', got %v", events.Metadata["values_sampled"])
+	}
+}
+
+func TestCompressibilityScanner_IncompressibleData(t *testing.T) {
+	random := make([]byte, 8192)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatal(err)
+	}
+
+	mock := newMockClient()
+	mock.scanKeys = []string{"blob:1"}
+	mock.values = map[string]string{"blob:1": string(random)}
+
+	s := &CompressibilityScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{LargeValueSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings for random data, got %d", len(findings))
+	}
+}
+
+func TestCompressedSize(t *testing.T) {
+	value := []byte(strings.Repeat("abc", 1000))
+	for _, codec := range compressionCodecs {
+		if got := compressedSize(codec, value); got <= 0 || got >= len(value) {
+			t.Errorf("compressedSize(%q) = %d, want between 0 and %d", codec, got, len(value))
+		}
+	}
+}
+
+// endlessHash pages through a hash of small fields that never ends.
+type endlessHash struct {
+	*mockClient
+	fields int
+}
+
+func (c *endlessHash) HScan(_ context.Context, _ string, cursor uint64, _ string, count int64) ([]string, uint64, error) {
+	pairs := make([]string, 0, count*2)
+	for i := int64(0); i < count; i++ {
+		pairs = append(pairs, fmt.Sprintf("f%d", cursor+uint64(i)), "1")
+	}
+	c.fields += int(count)
+	return pairs, cursor + uint64(count), nil
+}
+
+func TestCompressibilityScanner_SampleHashSmallFields(t *testing.T) {
+	client := &endlessHash{mockClient: newMockClient()}
+	s := &CompressibilityScanner{}
+
+	sampled := 0
+	scanned, ok := s.sampleHash(context.Background(), client, "big", newValueBudget(0), func([]byte) { sampled++ })
+	if sampled != 0 || !ok {
+		t.Errorf("expected no sampled values, got %d (ok=%v)", sampled, ok)
+	}
+	if scanned != client.fields {
+		t.Errorf("expected %d fields scanned, got %d", client.fields, scanned)
+	}
+	if client.fields > maxHashFieldsScanned+scanBatchSize {
+		t.Errorf("expected HSCAN to stop near %d fields, read %d", maxHashFieldsScanned, client.fields)
+	}
+}
+
+// largeHash is a hash of a million compressible fields.
+type largeHash struct {
+	*mockClient
+}
+
+func (c *largeHash) HScan(_ context.Context, _ string, cursor uint64, _ string, count int64) ([]string, uint64, error) {
+	pairs := make([]string, 0, count*2)
+	for i := int64(0); i < count; i++ {
+		pairs = append(pairs, fmt.Sprintf("f%d", cursor+uint64(i)), strings.Repeat(`{"ok":true}`, 20)[:200])
+	}
+	return pairs, cursor + uint64(count), nil
+}
+
+func (c *largeHash) HLen(_ context.Context, _ string) (int64, error) {
+	return 1000000, nil
+}
+
+func TestCompressibilityScanner_HashScaledToHLen(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"events:1"}
+	mock.hashes = map[string]map[string]string{"events:1": {"f": "v"}}
+	mock.memoryUsages = map[string]int64{"events:1": 300 << 20}
+
+	findings, err := (&CompressibilityScanner{}).Audit(context.Background(), &largeHash{mock}, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %v", findings)
+	}
+	f := findings[0]
+	if f.Metadata["values_sampled"] != maxHashFieldsSampled {
+		t.Errorf("expected %d fields sampled, got %v", maxHashFieldsSampled, f.Metadata["values_sampled"])
+	}
+	// 1,000 sampled 200-byte fields stand for all 1,000,000.
+	if got := f.Metadata["sampled_bytes"]; got != int64(200000000) {
+		t.Errorf("expected field bytes scaled to HLEN (200000000), got %v", got)
+	}
+	if f.Metadata["estimated_savings_bytes"].(int64) < 100000000 {
+		t.Errorf("expected savings scaled to the whole hash, got %v", f.Metadata["estimated_savings_bytes"])
+	}
+}
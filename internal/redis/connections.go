@@ -3,7 +3,36 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"time"
+)
+
+const (
+	// idleConnSeconds is how long a connection must be idle to count as idle.
+	idleConnSeconds = 300
+	// defaultIdleConnThreshold is the idle connection count per source IP,
+	// client name, or library above which a group is reported.
+	defaultIdleConnThreshold = 100
+	// clientBufferBytes and clientBufferHighBytes flag a single client whose
+	// query plus output buffers exceed them.
+	clientBufferBytes     = 16 * 1024 * 1024
+	clientBufferHighBytes = 256 * 1024 * 1024
+	// maxclientsWarnPercent and maxclientsHighPercent are maxclients
+	// utilization levels reported as medium and high.
+	maxclientsWarnPercent = 80
+	maxclientsHighPercent = 95
+	// rejectedMinPerHour is the average rejection rate over uptime that
+	// counts as current when there is no previous audit to compare with.
+	rejectedMinPerHour = 1
+	// connectionChurnPerSec and connectionChurnHighPerSec are new connections
+	// per second during the sample window reported as medium and high.
+	connectionChurnPerSec     = 10
+	connectionChurnHighPerSec = 100
+	// churnGroups caps the groups of new connections listed in a churn finding.
+	churnGroups = 5
+	// connectionGroupsInventory caps each group list in the inventory.
+	connectionGroupsInventory = 20
 )
 
 // ConnectionScanner audits Redis for connection waste indicators.
@@ -28,22 +57,301 @@ func (s *ConnectionScanner) Audit(ctx context.Context, client RedisClient, cfg A
 
 	connectedClients, _ := strconv.ParseInt(clientsInfo["connected_clients"], 10, 64)
 	blockedClients, _ := strconv.ParseInt(clientsInfo["blocked_clients"], 10, 64)
-	rejectedConnections, _ := strconv.ParseInt(statsInfo["rejected_connections"], 10, 64)
+	rejected := countersFor(cfg, statsInfo).Rate("rejected_connections")
 
-	if rejectedConnections > 0 {
+	if rejected.Active(rejectedMinPerHour) {
 		findings = append(findings, Finding{
 			ID:           FindingConnectionWaste,
 			Severity:     SeverityLow,
 			ResourceType: "Redis",
 			ResourceID:   cfg.Addr,
-			Message:      fmt.Sprintf("%d rejected connections detected", rejectedConnections),
+			Message:      fmt.Sprintf("%d rejected connections detected %s", rejected.Count, rejected.Describe()),
 			Metadata: map[string]any{
 				"connected_clients":    connectedClients,
 				"blocked_clients":      blockedClients,
-				"rejected_connections": rejectedConnections,
+				"rejected_connections": rejected.Count,
+				"rejected_per_hour":    rejected.PerHour(),
+				"counter_window":       rejected.Source,
 			},
 		})
 	}
 
+	// INFO clients reports maxclients from Redis 7.0, which covers servers
+	// that block CONFIG.
+	maxClients := infoInt(clientsInfo, "maxclients")
+	if cfg.supports(CapConfig) {
+		maxclientsConfig, err := client.ConfigGet(ctx, "maxclients")
+		if err != nil {
+			return nil, fmt.Errorf("config get maxclients: %w", err)
+		}
+		maxClients, _ = strconv.ParseInt(maxclientsConfig["maxclients"], 10, 64)
+	} else if maxClients == 0 {
+		cfg.skip(s.Name(), string(FindingMaxclientsUtilization), CapConfig)
+	}
+	var utilization float64
+	if maxClients > 0 {
+		utilization = float64(connectedClients) / float64(maxClients) * 100
+		if f, ok := s.maxclientsUtilization(cfg, connectedClients, maxClients, utilization); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	// CLIENT LIST is often denied to monitoring users; the INFO-based
+	// findings above still hold, so only the per-client checks are skipped.
+	raw, listErr := client.ClientList(ctx)
+	if listErr != nil {
+		for _, id := range []FindingID{FindingIdleConnections, FindingClientBufferMemory} {
+			cfg.skipped.add(SkippedCheck{
+				Auditor: s.Name(),
+				Check:   string(id),
+				Reason:  fmt.Sprintf("CLIENT LIST failed: %v", listErr),
+			})
+		}
+	}
+	clients := ParseClientList(raw)
+
+	threshold := cfg.IdleConnThreshold
+	if threshold <= 0 {
+		threshold = defaultIdleConnThreshold
+	}
+	groups := groupClients(clients)
+	for _, g := range groups {
+		if g.Idle < int64(threshold) {
+			continue
+		}
+		severity := SeverityLow
+		if g.Idle >= int64(threshold)*10 {
+			severity = SeverityMedium
+		}
+		findings = append(findings, Finding{
+			ID:           FindingIdleConnections,
+			Severity:     severity,
+			ResourceType: "Client",
+			ResourceID:   g.Kind + ":" + g.Value,
+			Message: fmt.Sprintf("%d of %d connections from %s %s idle for %ds or more",
+				g.Idle, g.Total, g.Kind, g.Value, idleConnSeconds),
+			Metadata: map[string]any{
+				"group_by":           g.Kind,
+				"group":              g.Value,
+				"connections":        g.Total,
+				"idle_connections":   g.Idle,
+				"max_idle_seconds":   g.MaxIdle,
+				"buffer_memory":      g.BufferBytes,
+				"idle_threshold_sec": idleConnSeconds,
+				"recommendation":     "close idle connections, shrink the client pool, or set the server timeout",
+			},
+		})
+	}
+
+	findings = append(findings, s.bufferFindings(clients)...)
+
+	if cfg.window != nil {
+		if f, ok := s.churn(cfg, clients, connectedClients); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	inventory := map[string]any{
+		"connected_clients":      connectedClients,
+		"maxclients":             maxClients,
+		"maxclients_utilization": utilization,
+	}
+	if listErr == nil {
+		inventory["client_list_size"] = len(clients)
+		inventory["groups"] = topClientGroups(groups)
+	}
+	cfg.inventory.set("connections", inventory)
+
 	return findings, nil
 }
+
+func (s *ConnectionScanner) maxclientsUtilization(cfg AuditConfig, connected, maxClients int64, utilization float64) (Finding, bool) {
+	var severity Severity
+	switch {
+	case utilization >= maxclientsHighPercent:
+		severity = SeverityHigh
+	case utilization >= maxclientsWarnPercent:
+		severity = SeverityMedium
+	default:
+		return Finding{}, false
+	}
+	return Finding{
+		ID:           FindingMaxclientsUtilization,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message:      fmt.Sprintf("%d of %d maxclients in use (%.1f%%)", connected, maxClients, utilization),
+		Metadata: map[string]any{
+			"connected_clients":   connected,
+			"maxclients":          maxClients,
+			"utilization_percent": utilization,
+			"recommendation":      "find the clients holding connections before raising maxclients",
+		},
+	}, true
+}
+
+// churn reports connections opened faster than the threshold during the
+// sample window, naming the groups that hold the youngest connections.
+func (s *ConnectionScanner) churn(cfg AuditConfig, clients []ClientInfo, connected int64) (Finding, bool) {
+	perSec := cfg.window.PerSecond("total_connections_received")
+	if perSec < connectionChurnPerSec {
+		return Finding{}, false
+	}
+	severity := SeverityMedium
+	if perSec >= connectionChurnHighPerSec {
+		severity = SeverityHigh
+	}
+
+	window := cfg.window.Duration()
+	var young []ClientInfo
+	for _, c := range clients {
+		if c.Age <= int64(window.Seconds()) {
+			young = append(young, c)
+		}
+	}
+	groups := groupClients(young)
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Total > groups[j].Total })
+	if len(groups) > churnGroups {
+		groups = groups[:churnGroups]
+	}
+
+	meta := map[string]any{
+		"connections_per_sec":      perSec,
+		"peak_connections_per_sec": cfg.window.PeakPerSecond("total_connections_received"),
+		"connected_clients":        connected,
+		"window_seconds":           window.Seconds(),
+		"new_connection_groups":    groups,
+		"recommendation":           "reuse connections through a client-side pool instead of connecting per request",
+	}
+	if ops := cfg.window.PerSecond("total_commands_processed"); ops > 0 {
+		meta["commands_per_connection"] = ops / perSec
+	}
+	return Finding{
+		ID:           FindingConnectionChurn,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("%.1f new connections/sec over %s with %d connected; clients are not pooling connections",
+			perSec, window.Round(time.Second), connected),
+		Metadata: meta,
+	}, true
+}
+
+func (s *ConnectionScanner) bufferFindings(clients []ClientInfo) []Finding {
+	var heavy []ClientInfo
+	for _, c := range clients {
+		if c.QBuf+c.OMem >= clientBufferBytes {
+			heavy = append(heavy, c)
+		}
+	}
+	sort.SliceStable(heavy, func(i, j int) bool {
+		return heavy[i].QBuf+heavy[i].OMem > heavy[j].QBuf+heavy[j].OMem
+	})
+
+	var findings []Finding
+	for _, c := range heavy {
+		buffers := c.QBuf + c.OMem
+		severity := SeverityMedium
+		if buffers >= clientBufferHighBytes {
+			severity = SeverityHigh
+		}
+		findings = append(findings, Finding{
+			ID:           FindingClientBufferMemory,
+			Severity:     severity,
+			ResourceType: "Client",
+			ResourceID:   c.Addr,
+			Message: fmt.Sprintf("client %s (%s) holds %s in buffers (qbuf %s, omem %s)",
+				c.Addr, clientLabel(c), FormatBytes(buffers), FormatBytes(c.QBuf), FormatBytes(c.OMem)),
+			Metadata: map[string]any{
+				"client_id": c.ID,
+				"name":      c.Name,
+				"lib_name":  c.LibName,
+				"user":      c.User,
+				"cmd":       c.Cmd,
+				"flags":     c.Flags,
+				"qbuf":      c.QBuf,
+				"omem":      c.OMem,
+				"tot_mem":   c.TotMem,
+			},
+		})
+	}
+	return findings
+}
+
+// clientLabel names a client by its connection name, falling back to its
+// library.
+func clientLabel(c ClientInfo) string {
+	switch {
+	case c.Name != "":
+		return "name " + c.Name
+	case c.LibName != "":
+		return "lib " + c.LibName
+	default:
+		return "unnamed"
+	}
+}
+
+// clientGroup aggregates connections sharing a source IP, client name, or
+// client library.
+type clientGroup struct {
+	Kind        string `json:"group_by"`
+	Value       string `json:"group"`
+	Total       int64  `json:"connections"`
+	Idle        int64  `json:"idle_connections"`
+	MaxIdle     int64  `json:"max_idle_seconds"`
+	BufferBytes int64  `json:"buffer_memory"`
+}
+
+// groupClients groups connections by source IP, name, and library, sorted by
+// idle count then total. Unnamed clients share the "(unnamed)" name group;
+// clients that did not report a library are left out of library groups.
+func groupClients(clients []ClientInfo) []*clientGroup {
+	index := make(map[string]*clientGroup)
+	var groups []*clientGroup
+	add := func(kind, value string, c ClientInfo) {
+		key := kind + "\x00" + value
+		g, ok := index[key]
+		if !ok {
+			g = &clientGroup{Kind: kind, Value: value}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.Total++
+		if c.Idle >= idleConnSeconds {
+			g.Idle++
+		}
+		g.MaxIdle = max(g.MaxIdle, c.Idle)
+		g.BufferBytes += c.QBuf + c.OMem
+	}
+
+	for _, c := range clients {
+		add("ip", c.IP(), c)
+		name := c.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		add("name", name, c)
+		if c.LibName != "" {
+			add("lib", c.LibName, c)
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].Idle != groups[j].Idle {
+			return groups[i].Idle > groups[j].Idle
+		}
+		return groups[i].Total > groups[j].Total
+	})
+	return groups
+}
+
+// topClientGroups returns the largest groups of each kind for the inventory.
+func topClientGroups(groups []*clientGroup) map[string][]*clientGroup {
+	top := make(map[string][]*clientGroup)
+	for _, g := range groups {
+		if len(top[g.Kind]) < connectionGroupsInventory {
+			top[g.Kind] = append(top[g.Kind], g)
+		}
+	}
+	return top
+}
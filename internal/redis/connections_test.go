@@ -2,7 +2,11 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestConnectionScanner_Name(t *testing.T) {
@@ -62,3 +66,205 @@ func TestConnectionScanner_MissingStats(t *testing.T) {
 		t.Errorf("expected 0 findings when stats missing, got %d", len(findings))
 	}
 }
+
+func TestConnectionScanner_IdleConnectionGroups(t *testing.T) {
+	var list strings.Builder
+	for i := 0; i < 5; i++ {
+		fmt.Fprintf(&list, "id=%d addr=10.0.0.5:%d name= lib-name=redis-py idle=900 qbuf=0 omem=0\n", i, 50000+i)
+	}
+	list.WriteString("id=10 addr=10.0.0.6:40000 name=api lib-name=go-redis idle=1 qbuf=0 omem=0\n")
+
+	mock := newMockClient()
+	mock.clientList = list.String()
+
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{IdleConnThreshold: 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := make(map[string]Finding)
+	for _, f := range findings {
+		if f.ID != FindingIdleConnections {
+			t.Errorf("unexpected finding %s", f.ID)
+		}
+		got[f.ResourceID] = f
+	}
+	for _, id := range []string{"ip:10.0.0.5", "name:(unnamed)", "lib:redis-py"} {
+		f, ok := got[id]
+		if !ok {
+			t.Errorf("expected IDLE_CONNECTIONS for %s, got %+v", id, findings)
+			continue
+		}
+		if f.Metadata["idle_connections"] != int64(5) {
+			t.Errorf("%s: expected 5 idle connections, got %v", id, f.Metadata["idle_connections"])
+		}
+	}
+	if len(findings) != 3 {
+		t.Errorf("expected 3 findings, got %d", len(findings))
+	}
+}
+
+func TestConnectionScanner_ClientBufferMemory(t *testing.T) {
+	mock := newMockClient()
+	mock.clientList = "id=1 addr=10.0.0.5:1 name=worker qbuf=0 omem=33554432 cmd=hgetall\n" +
+		"id=2 addr=10.0.0.5:2 name=bulk qbuf=300000000 omem=0 cmd=set\n" +
+		"id=3 addr=10.0.0.5:3 name=api qbuf=1024 omem=0 cmd=get\n"
+
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 findings, got %+v", findings)
+	}
+	if findings[0].ResourceID != "10.0.0.5:2" || findings[0].Severity != SeverityHigh {
+		t.Errorf("expected high finding for the largest buffer first, got %s %s", findings[0].ResourceID, findings[0].Severity)
+	}
+	if findings[1].ResourceID != "10.0.0.5:1" || findings[1].Severity != SeverityMedium {
+		t.Errorf("expected medium finding for 10.0.0.5:1, got %s %s", findings[1].ResourceID, findings[1].Severity)
+	}
+}
+
+func TestConnectionScanner_MaxclientsUtilization(t *testing.T) {
+	tests := []struct {
+		connected string
+		want      Severity
+	}{
+		{"500", ""},
+		{"850", SeverityMedium},
+		{"990", SeverityHigh},
+	}
+	for _, tt := range tests {
+		mock := newMockClient()
+		mock.infoResponses["clients"] = "connected_clients:" + tt.connected + "\n"
+		mock.configValues["maxclients"] = map[string]string{"maxclients": "1000"}
+		cfg := AuditConfig{inventory: &inventory{data: make(map[string]any)}}
+
+		s := &ConnectionScanner{}
+		findings, err := s.Audit(context.Background(), mock, cfg)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.want == "" {
+			if len(findings) != 0 {
+				t.Errorf("%s clients: expected 0 findings, got %+v", tt.connected, findings)
+			}
+		} else if len(findings) != 1 || findings[0].ID != FindingMaxclientsUtilization || findings[0].Severity != tt.want {
+			t.Errorf("%s clients: expected %s MAXCLIENTS_UTILIZATION, got %+v", tt.connected, tt.want, findings)
+		}
+		inv, ok := cfg.inventory.data["connections"].(map[string]any)
+		if !ok {
+			t.Fatal("expected connections inventory")
+		}
+		if inv["maxclients"] != int64(1000) {
+			t.Errorf("expected maxclients 1000 in inventory, got %v", inv["maxclients"])
+		}
+	}
+}
+
+func TestConnectionScanner_ClientListError(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["clients"] = "# Clients\nconnected_clients:990\n"
+	mock.infoResponses["stats"] = "# Stats\nrejected_connections:15\n"
+	mock.configValues["maxclients"] = map[string]string{"maxclients": "1000"}
+	mock.clientListErr = errors.New("NOPERM this user has no permissions to run the 'client|list' command")
+	cfg := AuditConfig{skipped: &skipList{}, inventory: &inventory{data: make(map[string]any)}}
+
+	findings, err := (&ConnectionScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("expected CLIENT LIST failure to be skipped, got %v", err)
+	}
+	byID := findingsByID(findings)
+	if len(byID[FindingConnectionWaste]) != 1 || len(byID[FindingMaxclientsUtilization]) != 1 {
+		t.Errorf("expected the INFO-based findings, got %v", findings)
+	}
+	if len(cfg.skipped.checks) != 2 {
+		t.Fatalf("expected 2 skipped checks, got %v", cfg.skipped.checks)
+	}
+	for _, c := range cfg.skipped.checks {
+		if !strings.Contains(c.Reason, "NOPERM") {
+			t.Errorf("expected the CLIENT LIST error as the reason, got %q", c.Reason)
+		}
+	}
+	inv := cfg.inventory.data["connections"].(map[string]any)
+	if _, ok := inv["client_list_size"]; ok {
+		t.Errorf("expected no client list size without CLIENT LIST, got %v", inv)
+	}
+}
+
+func TestConnectionScanner_StaleRejections(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["stats"] = "# Stats\nrejected_connections:1\n"
+	// One rejection over eight months of uptime, no previous audit.
+	snap := NewSnapshot(map[string]string{"uptime_in_seconds": "20736000", "rejected_connections": "1"}, time.Now())
+
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{counters: NewCounters(snap, nil)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected stale rejections to be ignored, got %+v", findings)
+	}
+}
+
+func TestConnectionScanner_RejectionsSinceLastAudit(t *testing.T) {
+	now := time.Now()
+	prev := Snapshot{Time: now.Add(-time.Hour), RunID: "abc", Uptime: 20732400, Counters: map[string]int64{"rejected_connections": 1}}
+	snap := Snapshot{Time: now, RunID: "abc", Uptime: 20736000, Counters: map[string]int64{"rejected_connections": 6}}
+
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), newMockClient(), AuditConfig{counters: NewCounters(snap, &prev)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].Metadata["rejected_connections"] != int64(5) {
+		t.Fatalf("expected 1 finding with 5 new rejections, got %+v", findings)
+	}
+	if findings[0].Metadata["counter_window"] != RateSinceLastAudit {
+		t.Errorf("expected since_last_audit window, got %v", findings[0].Metadata["counter_window"])
+	}
+}
+
+func TestConnectionScanner_Churn(t *testing.T) {
+	mock := newMockClient()
+	mock.clientList = "id=1 addr=10.0.0.9:1 name=php-fpm age=1 idle=0\n" +
+		"id=2 addr=10.0.0.9:2 name=php-fpm age=0 idle=0\n" +
+		"id=3 addr=10.0.0.3:1 name=api age=86400 idle=0\n"
+	w := windowOf("total_connections_received", 0, 3000)
+	for i, ops := range []int64{0, 9000} {
+		w.Samples[i].Counters["total_commands_processed"] = ops
+	}
+
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{window: w})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := findingsByID(findings)[FindingConnectionChurn]
+	if len(got) != 1 {
+		t.Fatalf("expected 1 CONNECTION_CHURN finding, got %+v", findings)
+	}
+	if got[0].Severity != SeverityHigh {
+		t.Errorf("expected high severity at 300/s, got %s", got[0].Severity)
+	}
+	if cpc := got[0].Metadata["commands_per_connection"].(float64); cpc != 3 {
+		t.Errorf("expected 3 commands per connection, got %v", cpc)
+	}
+	groups := got[0].Metadata["new_connection_groups"].([]*clientGroup)
+	if len(groups) == 0 || groups[0].Total != 2 {
+		t.Errorf("expected the largest new-connection group to hold 2 clients, got %+v", groups)
+	}
+}
+
+func TestConnectionScanner_NoChurnBelowThreshold(t *testing.T) {
+	s := &ConnectionScanner{}
+	findings, err := s.Audit(context.Background(), newMockClient(), AuditConfig{window: windowOf("total_connections_received", 0, 50)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findingsByID(findings)[FindingConnectionChurn]) != 0 {
+		t.Errorf("expected no churn finding at 5/s, got %+v", findings)
+	}
+}
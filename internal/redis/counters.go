@@ -0,0 +1,231 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// recentRestartWindow is the uptime below which a server counts as recently
+// restarted and its counters cover too little time to judge.
+const recentRestartWindow = time.Hour
+
+// snapshotCounters are the cumulative INFO server, stats, and replication
+// counters kept in a Snapshot.
+var snapshotCounters = []string{
+	"total_connections_received",
+	"rejected_connections",
+	"total_commands_processed",
+	"total_net_input_bytes",
+	"total_net_output_bytes",
+	"keyspace_hits",
+	"keyspace_misses",
+	"evicted_keys",
+	"expired_keys",
+	"sync_full",
+	"sync_partial_ok",
+	"sync_partial_err",
+	"total_error_replies",
+	"master_repl_offset",
+}
+
+// commandCounterFields are the cumulative fields of an INFO commandstats
+// entry kept in a Snapshot, under commandCounter names.
+var commandCounterFields = []string{"calls", "usec", "failed_calls", "rejected_calls"}
+
+// commandCounter names a per-command counter in a Snapshot, such as
+// "cmdstat_keys.calls".
+func commandCounter(command, field string) string {
+	return "cmdstat_" + command + "." + field
+}
+
+// errorCounter names a per-error-type counter in a Snapshot, such as
+// "errorstat_WRONGTYPE.count".
+func errorCounter(errorType string) string {
+	return "errorstat_" + errorType + ".count"
+}
+
+// Snapshot is the cumulative counters of one server at one point in time.
+// Snapshots are saved in the state file so the next run can compute deltas.
+type Snapshot struct {
+	Time     time.Time        `json:"time"`
+	RunID    string           `json:"run_id"`
+	Uptime   int64            `json:"uptime_in_seconds"`
+	Counters map[string]int64 `json:"counters"`
+}
+
+// NewSnapshot extracts the counters from parsed INFO fields taken at t.
+func NewSnapshot(info map[string]string, t time.Time) Snapshot {
+	s := Snapshot{
+		Time:     t,
+		RunID:    info["run_id"],
+		Uptime:   infoInt(info, "uptime_in_seconds"),
+		Counters: make(map[string]int64, len(snapshotCounters)),
+	}
+	for _, name := range snapshotCounters {
+		if _, ok := info[name]; ok {
+			s.Counters[name] = infoInt(info, name)
+		}
+	}
+	for name, value := range info {
+		if cmd, ok := strings.CutPrefix(name, "cmdstat_"); ok {
+			fields := ParseInfoFields(value)
+			for _, field := range commandCounterFields {
+				if _, ok := fields[field]; ok {
+					s.Counters[commandCounter(cmd, field)] = infoInt(fields, field)
+				}
+			}
+		} else if typ, ok := strings.CutPrefix(name, "errorstat_"); ok {
+			s.Counters[errorCounter(typ)] = infoInt(ParseInfoFields(value), "count")
+		}
+	}
+	return s
+}
+
+// snapshotSections are the INFO sections a snapshot reads.
+var snapshotSections = []string{"server", "stats", "replication", "commandstats", "errorstats"}
+
+// TakeSnapshot reads the INFO sections holding cumulative counters and
+// returns their counters.
+func TakeSnapshot(ctx context.Context, client RedisClient) (Snapshot, error) {
+	info := make(map[string]string)
+	for _, section := range snapshotSections {
+		raw, err := client.Info(ctx, section)
+		if err != nil {
+			return Snapshot{}, fmt.Errorf("info %s: %w", section, err)
+		}
+		for k, v := range ParseInfo(raw) {
+			info[k] = v
+		}
+	}
+	return NewSnapshot(info, time.Now()), nil
+}
+
+// Rate sources describe the period a counter value covers.
+const (
+	RateSinceLastAudit = "since_last_audit"
+	RateSinceRestart   = "since_restart"
+	RateCumulative     = "cumulative"
+)
+
+// Rate is a counter's increase over a known window.
+type Rate struct {
+	Count  int64
+	Window time.Duration
+	Source string
+}
+
+// PerHour returns the count per hour of the window, or 0 when the window is
+// unknown.
+func (r Rate) PerHour() float64 {
+	if r.Window <= 0 {
+		return 0
+	}
+	return float64(r.Count) / r.Window.Hours()
+}
+
+// Active reports whether the counter describes current behavior: it grew
+// since the last audit, or over uptime it averages at least minPerHour. With
+// no uptime to compare against, any nonzero count counts.
+func (r Rate) Active(minPerHour float64) bool {
+	if r.Count <= 0 {
+		return false
+	}
+	switch r.Source {
+	case RateSinceLastAudit, RateCumulative:
+		return true
+	default:
+		return r.PerHour() >= minPerHour
+	}
+}
+
+// Describe renders the window for finding messages.
+func (r Rate) Describe() string {
+	switch r.Source {
+	case RateSinceLastAudit:
+		return "since the last audit " + formatUptime(r.Window) + " ago"
+	case RateSinceRestart:
+		return "over " + formatUptime(r.Window) + " of uptime"
+	default:
+		return "since the counters were reset"
+	}
+}
+
+// Counters turns cumulative INFO counters into rates, using the previous
+// run's snapshot when it is from the same server process.
+type Counters struct {
+	current  Snapshot
+	previous *Snapshot
+}
+
+// NewCounters returns counters for current, with deltas against previous
+// when it is non-nil.
+func NewCounters(current Snapshot, previous *Snapshot) *Counters {
+	return &Counters{current: current, previous: previous}
+}
+
+// countersFor returns the run's counters, or counters built from INFO fields
+// the auditor already read when it runs outside MultiAuditor.
+func countersFor(cfg AuditConfig, info ...map[string]string) *Counters {
+	if cfg.counters != nil {
+		return cfg.counters
+	}
+	merged := make(map[string]string)
+	for _, m := range info {
+		for k, v := range m {
+			merged[k] = v
+		}
+	}
+	return NewCounters(NewSnapshot(merged, time.Now()), nil)
+}
+
+// Uptime returns the server uptime.
+func (c *Counters) Uptime() time.Duration {
+	return time.Duration(c.current.Uptime) * time.Second
+}
+
+// RestartedSinceLastAudit reports whether the server restarted after the
+// previous snapshot was taken.
+func (c *Counters) RestartedSinceLastAudit() bool {
+	if c.previous == nil {
+		return false
+	}
+	if c.previous.RunID != "" && c.current.RunID != "" && c.previous.RunID != c.current.RunID {
+		return true
+	}
+	return c.current.Uptime < c.previous.Uptime
+}
+
+// RecentlyRestarted reports whether the server has been up for less than an
+// hour, so its counters cover too little time to judge.
+func (c *Counters) RecentlyRestarted() bool {
+	return c.current.Uptime > 0 && c.Uptime() < recentRestartWindow
+}
+
+// Rate returns the increase of a counter since the last audit, or since the
+// restart when there is no usable previous snapshot.
+func (c *Counters) Rate(name string) Rate {
+	value := c.current.Counters[name]
+	if c.previous != nil && !c.RestartedSinceLastAudit() {
+		if prev, ok := c.previous.Counters[name]; ok && value >= prev {
+			return Rate{
+				Count:  value - prev,
+				Window: c.current.Time.Sub(c.previous.Time),
+				Source: RateSinceLastAudit,
+			}
+		}
+	}
+	if c.current.Uptime > 0 {
+		return Rate{Count: value, Window: c.Uptime(), Source: RateSinceRestart}
+	}
+	return Rate{Count: value, Source: RateCumulative}
+}
+
+// State is the state file: the last snapshot per audit target.
+type State map[string]Snapshot
+
+// StateKey identifies an audit target in the state file.
+func StateKey(addr string, db int) string {
+	return fmt.Sprintf("%s/%d", addr, db)
+}
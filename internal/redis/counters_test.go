@@ -0,0 +1,140 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewSnapshot(t *testing.T) {
+	info := ParseInfo("run_id:abc\r\nuptime_in_seconds:7200\r\nrejected_connections:4\r\nkeyspace_hits:100\r\nconnected_clients:3\r\n")
+	s := NewSnapshot(info, time.Unix(1000, 0))
+	if s.RunID != "abc" || s.Uptime != 7200 {
+		t.Errorf("unexpected snapshot header: %+v", s)
+	}
+	if s.Counters["rejected_connections"] != 4 || s.Counters["keyspace_hits"] != 100 {
+		t.Errorf("unexpected counters: %v", s.Counters)
+	}
+	if _, ok := s.Counters["connected_clients"]; ok {
+		t.Error("expected gauges to be left out")
+	}
+}
+
+func TestNewSnapshot_CommandCounters(t *testing.T) {
+	info := ParseInfo("cmdstat_keys:calls=3,usec=90000,usec_per_call=30000.00,rejected_calls=0,failed_calls=1\r\n" +
+		"errorstat_WRONGTYPE:count=70\r\nmaster_repl_offset:5000\r\n")
+	s := NewSnapshot(info, time.Unix(1000, 0))
+	want := map[string]int64{
+		"cmdstat_keys.calls":        3,
+		"cmdstat_keys.usec":         90000,
+		"cmdstat_keys.failed_calls": 1,
+		"errorstat_WRONGTYPE.count": 70,
+		"master_repl_offset":        5000,
+	}
+	for name, v := range want {
+		if s.Counters[name] != v {
+			t.Errorf("%s: expected %d, got %d", name, v, s.Counters[name])
+		}
+	}
+	if _, ok := s.Counters["cmdstat_keys.usec_per_call"]; ok {
+		t.Error("expected the per-call average to be left out")
+	}
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["server"] = "run_id:abc\r\nuptime_in_seconds:60\r\n"
+	mock.infoResponses["stats"] = "evicted_keys:7\r\n"
+
+	s, err := TakeSnapshot(context.Background(), mock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s.RunID != "abc" || s.Counters["evicted_keys"] != 7 {
+		t.Errorf("unexpected snapshot: %+v", s)
+	}
+}
+
+func TestCounters_Rate(t *testing.T) {
+	now := time.Now()
+	prev := Snapshot{Time: now.Add(-2 * time.Hour), RunID: "abc", Uptime: 86400, Counters: map[string]int64{"rejected_connections": 40}}
+
+	tests := []struct {
+		name       string
+		current    Snapshot
+		previous   *Snapshot
+		wantCount  int64
+		wantSource string
+		wantHour   float64
+	}{
+		{
+			name:       "delta since last audit",
+			current:    Snapshot{Time: now, RunID: "abc", Uptime: 86400 + 7200, Counters: map[string]int64{"rejected_connections": 50}},
+			previous:   &prev,
+			wantCount:  10,
+			wantSource: RateSinceLastAudit,
+			wantHour:   5,
+		},
+		{
+			name:       "restart discards previous",
+			current:    Snapshot{Time: now, RunID: "def", Uptime: 3600, Counters: map[string]int64{"rejected_connections": 3}},
+			previous:   &prev,
+			wantCount:  3,
+			wantSource: RateSinceRestart,
+			wantHour:   3,
+		},
+		{
+			name:       "no previous state",
+			current:    Snapshot{Time: now, Uptime: 7200, Counters: map[string]int64{"rejected_connections": 4}},
+			wantCount:  4,
+			wantSource: RateSinceRestart,
+			wantHour:   2,
+		},
+		{
+			name:       "unknown uptime",
+			current:    Snapshot{Time: now, Counters: map[string]int64{"rejected_connections": 4}},
+			wantCount:  4,
+			wantSource: RateCumulative,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewCounters(tt.current, tt.previous).Rate("rejected_connections")
+			if r.Count != tt.wantCount || r.Source != tt.wantSource {
+				t.Errorf("expected %d %s, got %d %s", tt.wantCount, tt.wantSource, r.Count, r.Source)
+			}
+			if got := r.PerHour(); got < tt.wantHour-0.01 || got > tt.wantHour+0.01 {
+				t.Errorf("expected %.2f per hour, got %.2f", tt.wantHour, got)
+			}
+		})
+	}
+}
+
+func TestRate_Active(t *testing.T) {
+	stale := Rate{Count: 1, Window: 240 * 24 * time.Hour, Source: RateSinceRestart}
+	if stale.Active(1) {
+		t.Error("expected one rejection over eight months not to be active")
+	}
+	if !(Rate{Count: 1, Window: time.Hour, Source: RateSinceLastAudit}).Active(1000) {
+		t.Error("expected any increase since the last audit to be active")
+	}
+	if (Rate{Source: RateSinceLastAudit}).Active(0) {
+		t.Error("expected zero count to be inactive")
+	}
+}
+
+func TestCounters_Restarts(t *testing.T) {
+	prev := &Snapshot{RunID: "abc", Uptime: 5000}
+	if !NewCounters(Snapshot{RunID: "def", Uptime: 9000}, prev).RestartedSinceLastAudit() {
+		t.Error("expected a changed run_id to be a restart")
+	}
+	if !NewCounters(Snapshot{Uptime: 100}, &Snapshot{Uptime: 5000}).RestartedSinceLastAudit() {
+		t.Error("expected lower uptime to be a restart")
+	}
+	if NewCounters(Snapshot{RunID: "abc", Uptime: 9000}, prev).RestartedSinceLastAudit() {
+		t.Error("expected no restart")
+	}
+	if !NewCounters(Snapshot{Uptime: 600}, nil).RecentlyRestarted() {
+		t.Error("expected 10 minutes of uptime to be a recent restart")
+	}
+}
@@ -0,0 +1,145 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"sort"
+)
+
+const maxDuplicateKeysListed = 20
+
+// DuplicateValueScanner finds large string values stored under more than one
+// key. Values are hashed while streaming and never retained or reported.
+type DuplicateValueScanner struct{}
+
+func (s *DuplicateValueScanner) Name() string { return "duplicates" }
+
+type duplicateGroup struct {
+	size int64
+	keys []string
+}
+
+func (s *DuplicateValueScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	minSize := largeValueSize(cfg)
+	budget := newValueBudget(cfg.ValueBudget)
+	groups := make(map[string]*duplicateGroup)
+	exhausted := false
+	overBudget := 0
+
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		keyType, err := client.Type(ctx, key)
+		if err != nil || keyType != "string" {
+			return true
+		}
+		length, err := client.StrLen(ctx, key)
+		if err != nil || length < minSize {
+			return true
+		}
+		// A value larger than what is left is skipped; the walk stops once
+		// no value of the minimum size fits.
+		if !budget.take(length) {
+			overBudget++
+			exhausted = budget.remaining < minSize
+			return !exhausted
+		}
+
+		h := sha256.New()
+		if err := streamStringValue(ctx, client, key, length, h); err != nil {
+			slog.Debug("Skipping value", "key", key, "error", err)
+			return true
+		}
+		sum := hex.EncodeToString(h.Sum(nil))
+
+		g, ok := groups[sum]
+		if !ok {
+			g = &duplicateGroup{size: length}
+			groups[sum] = g
+		}
+		g.keys = append(g.keys, key)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if exhausted || overBudget > 0 {
+		slog.Debug("Value budget exhausted, duplicate detection used a partial sample", "auditor", s.Name(), "over_budget", overBudget)
+	}
+
+	var findings []Finding
+	for sum, g := range groups {
+		if len(g.keys) < 2 {
+			continue
+		}
+		findings = append(findings, s.groupFinding(ctx, client, sum, g, exhausted || overBudget > 0, overBudget))
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		ri := findings[i].Metadata["reclaimable_bytes"].(int64)
+		rj := findings[j].Metadata["reclaimable_bytes"].(int64)
+		if ri != rj {
+			return ri > rj
+		}
+		return findings[i].ResourceID < findings[j].ResourceID
+	})
+
+	return findings, nil
+}
+
+func (s *DuplicateValueScanner) groupFinding(ctx context.Context, client RedisClient, sum string, g *duplicateGroup, partial bool, overBudget int) Finding {
+	sort.Strings(g.keys)
+
+	var total, largest int64
+	namespaces := make(map[string]bool)
+	for _, key := range g.keys {
+		mem, err := client.MemoryUsage(ctx, key)
+		if err != nil {
+			mem = g.size
+		}
+		total += mem
+		if mem > largest {
+			largest = mem
+		}
+		namespaces[keyNamespace(key)] = true
+	}
+	// Deduplicating keeps one copy and frees the rest.
+	reclaimable := total - largest
+
+	nsList := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		nsList = append(nsList, ns)
+	}
+	sort.Strings(nsList)
+
+	listed := g.keys
+	if len(listed) > maxDuplicateKeysListed {
+		listed = listed[:maxDuplicateKeysListed]
+	}
+
+	severity := SeverityLow
+	if reclaimable >= defaultBigKeySize {
+		severity = SeverityMedium
+	}
+
+	return Finding{
+		ID:           FindingDuplicateValue,
+		Severity:     severity,
+		ResourceType: "ValueGroup",
+		ResourceID:   "sha256:" + sum[:16],
+		Message: fmt.Sprintf("%d keys across %d namespace(s) store the same %s value; deduplication would free %s",
+			len(g.keys), len(nsList), FormatBytes(g.size), FormatBytes(reclaimable)),
+		Metadata: map[string]any{
+			"keys":               listed,
+			"key_count":          len(g.keys),
+			"namespaces":         nsList,
+			"value_bytes":        g.size,
+			"total_bytes":        total,
+			"reclaimable_bytes":  reclaimable,
+			"reclaimable_human":  FormatBytes(reclaimable),
+			"partial_sample":     partial,
+			"values_over_budget": overBudget,
+		},
+	}
+}
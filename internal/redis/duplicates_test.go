@@ -0,0 +1,112 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestDuplicateValueScanner_Name(t *testing.T) {
+	s := &DuplicateValueScanner{}
+	if s.Name() != "duplicates" {
+		t.Errorf("expected name 'duplicates', got %q", s.Name())
+	}
+}
+
+func TestDuplicateValueScanner_GroupsIdenticalValues(t *testing.T) {
+	blob := strings.Repeat("cached-blob-", 1000)
+	mock := newMockClient()
+	mock.scanKeys = []string{"svc-a:profile:1", "svc-b:profile:1", "svc-a:other", "small:1", "small:2"}
+	mock.values = map[string]string{
+		"svc-a:profile:1": blob,
+		"svc-b:profile:1": blob,
+		"svc-a:other":     strings.Repeat("x", 12000),
+		"small:1":         "tiny",
+		"small:2":         "tiny",
+	}
+	mock.memoryUsages = map[string]int64{
+		"svc-a:profile:1": 12100,
+		"svc-b:profile:1": 12100,
+	}
+
+	s := &DuplicateValueScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{LargeValueSize: 1024})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 duplicate group, got %d", len(findings))
+	}
+
+	f := findings[0]
+	if f.ID != FindingDuplicateValue {
+		t.Errorf("expected finding ID %q, got %q", FindingDuplicateValue, f.ID)
+	}
+	if f.Metadata["key_count"] != 2 {
+		t.Errorf("expected key_count 2, got %v", f.Metadata["key_count"])
+	}
+	if f.Metadata["reclaimable_bytes"] != int64(12100) {
+		t.Errorf("expected reclaimable_bytes 12100, got %v", f.Metadata["reclaimable_bytes"])
+	}
+	ns := f.Metadata["namespaces"].([]string)
+	if len(ns) != 2 || ns[0] != "svc-a" || ns[1] != "svc-b" {
+		t.Errorf("expected namespaces [svc-a svc-b], got %v", ns)
+	}
+	if strings.Contains(f.Message, "cached-blob") {
+		t.Error("finding message must not contain the value")
+	}
+	for _, v := range f.Metadata {
+		if s, ok := v.(string); ok && strings.Contains(s, "cached-blob") {
+			t.Error("finding metadata must not contain the value")
+		}
+	}
+}
+
+func TestDuplicateValueScanner_RespectsBudget(t *testing.T) {
+	blob := strings.Repeat("a", 4096)
+	mock := newMockClient()
+	mock.scanKeys = []string{"k1", "k2"}
+	mock.values = map[string]string{"k1": blob, "k2": blob}
+
+	s := &DuplicateValueScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{LargeValueSize: 1024, ValueBudget: 5000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings once budget is exhausted, got %d", len(findings))
+	}
+}
+
+func TestDuplicateValueScanner_SkipsOversizedValue(t *testing.T) {
+	small := strings.Repeat("b", 2048)
+	mock := newMockClient()
+	mock.scanKeys = []string{"huge", "k1", "k2"}
+	mock.values = map[string]string{"huge": strings.Repeat("a", 8192), "k1": small, "k2": small}
+
+	findings, err := (&DuplicateValueScanner{}).Audit(context.Background(), mock, AuditConfig{LargeValueSize: 1024, ValueBudget: 5000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected the smaller duplicates after an oversized value, got %d", len(findings))
+	}
+	if findings[0].Metadata["values_over_budget"] != 1 || findings[0].Metadata["partial_sample"] != true {
+		t.Errorf("expected one value skipped over budget, got %v", findings[0].Metadata)
+	}
+}
+
+func TestDuplicateValueScanner_SkipsNonStrings(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"h1", "h2"}
+	mock.keyTypes = map[string]string{"h1": "hash", "h2": "hash"}
+
+	s := &DuplicateValueScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
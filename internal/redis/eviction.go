@@ -4,9 +4,33 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
 )
 
-// EvictionScanner audits Redis eviction policy configuration.
+const (
+	// maxmemoryShare and maxmemoryPersistShare are the fractions of system
+	// memory suggested for maxmemory without and with persistence.
+	maxmemoryShare        = 0.75
+	maxmemoryPersistShare = 0.5
+	// forkHeadroom is the share of the dataset assumed to be duplicated by
+	// copy-on-write while an RDB save or AOF rewrite child is running.
+	forkHeadroom = 0.5
+	// oversizedRatio flags maxmemory this many times larger than RSS.
+	oversizedRatio = 4
+	// oversizedMinBytes skips the oversized check for small limits.
+	oversizedMinBytes = 1 << 30 // 1 GB
+	// volatileMinExpiresRatio is the share of keys with a TTL below which a
+	// volatile-* policy has too little to evict.
+	volatileMinExpiresRatio = 0.1
+	// evictionStormPerSec and evictionStormHighPerSec are evictions per
+	// second during the sample window reported as medium and high.
+	evictionStormPerSec     = 100
+	evictionStormHighPerSec = 1000
+)
+
+// EvictionScanner audits Redis maxmemory sizing and eviction policy
+// configuration.
 type EvictionScanner struct{}
 
 func (s *EvictionScanner) Name() string { return "eviction" }
@@ -14,11 +38,10 @@ func (s *EvictionScanner) Name() string { return "eviction" }
 func (s *EvictionScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
 	var findings []Finding
 
-	policyConfig, err := client.ConfigGet(ctx, "maxmemory-policy")
+	policy, err := maxmemoryPolicy(ctx, client, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("config get maxmemory-policy: %w", err)
+		return nil, err
 	}
-	policy := policyConfig["maxmemory-policy"]
 
 	raw, err := client.Info(ctx, "memory")
 	if err != nil {
@@ -28,6 +51,18 @@ func (s *EvictionScanner) Audit(ctx context.Context, client RedisClient, cfg Aud
 
 	usedMemory, _ := strconv.ParseInt(info["used_memory"], 10, 64)
 	maxMemory, _ := strconv.ParseInt(info["maxmemory"], 10, 64)
+	systemMemory := infoInt(info, "total_system_memory")
+
+	persistence, err := persistenceEnabled(ctx, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if maxMemory == 0 {
+		findings = append(findings, s.maxmemoryNotSet(cfg, usedMemory, systemMemory, persistence))
+	} else if f, ok := s.overcommit(cfg, maxMemory, systemMemory, persistence); ok {
+		findings = append(findings, f)
+	}
 
 	if policy == "noeviction" && maxMemory > 0 {
 		usagePercent := float64(usedMemory) / float64(maxMemory) * 100
@@ -49,5 +84,208 @@ func (s *EvictionScanner) Audit(ctx context.Context, client RedisClient, cfg Aud
 		}
 	}
 
+	if f, ok := s.oversized(cfg, info, maxMemory); ok {
+		findings = append(findings, f)
+	}
+
+	if cfg.window != nil {
+		if f, ok := s.storm(cfg, policy, usedMemory, maxMemory); ok {
+			findings = append(findings, f)
+		}
+	}
+
+	if maxMemory > 0 {
+		keyspaceRaw, err := client.Info(ctx, "keyspace")
+		if err != nil {
+			return nil, fmt.Errorf("info keyspace: %w", err)
+		}
+		keys, expires := keyspaceTotals(ParseInfo(keyspaceRaw))
+		if f, ok := s.policyMismatch(cfg, policy, keys, expires); ok {
+			findings = append(findings, f)
+		}
+	}
+
 	return findings, nil
 }
+
+// suggestedMaxmemory returns a maxmemory value that leaves room for the OS
+// and, when persistence is on, for copy-on-write during forks.
+func suggestedMaxmemory(systemMemory int64, persistence bool) int64 {
+	share := maxmemoryShare
+	if persistence {
+		share = maxmemoryPersistShare
+	}
+	const mb = 1024 * 1024
+	return int64(float64(systemMemory)*share) / mb * mb
+}
+
+func (s *EvictionScanner) maxmemoryNotSet(cfg AuditConfig, usedMemory, systemMemory int64, persistence bool) Finding {
+	meta := map[string]any{
+		"maxmemory":           0,
+		"used_memory":         usedMemory,
+		"total_system_memory": systemMemory,
+		"persistence":         persistence,
+		"recommendation":      "set maxmemory so the dataset cannot grow until the OS kills the server",
+	}
+	if systemMemory > 0 {
+		suggested := suggestedMaxmemory(systemMemory, persistence)
+		meta["suggested_maxmemory"] = suggested
+		meta["suggested_config"] = fmt.Sprintf("maxmemory %dmb", suggested/(1024*1024))
+	}
+	return Finding{
+		ID:           FindingMaxmemoryNotSet,
+		Severity:     SeverityHigh,
+		ResourceType: "Config",
+		ResourceID:   cfg.Addr,
+		Message:      fmt.Sprintf("maxmemory is not set; memory grows without limit (used: %s)", FormatBytes(usedMemory)),
+		Metadata:     meta,
+	}
+}
+
+func (s *EvictionScanner) overcommit(cfg AuditConfig, maxMemory, systemMemory int64, persistence bool) (Finding, bool) {
+	if systemMemory == 0 {
+		return Finding{}, false
+	}
+
+	var severity Severity
+	var message string
+	switch {
+	case maxMemory > systemMemory:
+		severity = SeverityCritical
+		message = fmt.Sprintf("maxmemory %s exceeds system memory %s", FormatBytes(maxMemory), FormatBytes(systemMemory))
+	case persistence && float64(maxMemory)*(1+forkHeadroom) > float64(systemMemory):
+		severity = SeverityHigh
+		message = fmt.Sprintf("maxmemory %s leaves too little of system memory %s for copy-on-write during persistence forks",
+			FormatBytes(maxMemory), FormatBytes(systemMemory))
+	default:
+		return Finding{}, false
+	}
+
+	suggested := suggestedMaxmemory(systemMemory, persistence)
+	return Finding{
+		ID:           FindingMaxmemoryOvercommit,
+		Severity:     severity,
+		ResourceType: "Config",
+		ResourceID:   cfg.Addr,
+		Message:      message,
+		Metadata: map[string]any{
+			"maxmemory":           maxMemory,
+			"total_system_memory": systemMemory,
+			"persistence":         persistence,
+			"suggested_maxmemory": suggested,
+			"suggested_config":    fmt.Sprintf("maxmemory %dmb", suggested/(1024*1024)),
+		},
+	}, true
+}
+
+func (s *EvictionScanner) oversized(cfg AuditConfig, info map[string]string, maxMemory int64) (Finding, bool) {
+	rss := infoInt(info, "used_memory_rss")
+	if maxMemory < oversizedMinBytes || rss == 0 || rss*oversizedRatio > maxMemory {
+		return Finding{}, false
+	}
+	peak := max(infoInt(info, "used_memory_peak"), rss)
+	suggested := roundUpMB(peak * 2)
+	return Finding{
+		ID:           FindingMaxmemoryOversized,
+		Severity:     SeverityLow,
+		ResourceType: "Config",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("maxmemory %s is %.0fx the resident memory %s; the instance is likely oversized",
+			FormatBytes(maxMemory), float64(maxMemory)/float64(rss), FormatBytes(rss)),
+		Metadata: map[string]any{
+			"maxmemory":           maxMemory,
+			"used_memory_rss":     rss,
+			"used_memory_peak":    peak,
+			"suggested_maxmemory": suggested,
+			"suggested_config":    fmt.Sprintf("maxmemory %dmb", suggested/(1024*1024)),
+			"recommendation":      "move to a smaller instance or lower maxmemory to twice the peak usage",
+		},
+	}, true
+}
+
+func (s *EvictionScanner) policyMismatch(cfg AuditConfig, policy string, keys, expires int64) (Finding, bool) {
+	if keys == 0 {
+		return Finding{}, false
+	}
+	ratio := float64(expires) / float64(keys)
+	meta := map[string]any{
+		"policy":        policy,
+		"keys":          keys,
+		"expires":       expires,
+		"expires_ratio": ratio,
+	}
+
+	switch {
+	case strings.HasPrefix(policy, "volatile-") && ratio < volatileMinExpiresRatio:
+		severity := SeverityMedium
+		if expires == 0 {
+			severity = SeverityHigh
+		}
+		meta["suggested_config"] = "maxmemory-policy " + strings.Replace(policy, "volatile-", "allkeys-", 1)
+		if policy == "volatile-ttl" {
+			meta["suggested_config"] = "maxmemory-policy allkeys-lru"
+		}
+		return Finding{
+			ID:           FindingEvictionPolicyMismatch,
+			Severity:     severity,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message: fmt.Sprintf("%s only evicts keys with a TTL, but %d of %d keys have one; writes fail once memory is full",
+				policy, expires, keys),
+			Metadata: meta,
+		}, true
+	case policy == "noeviction" && expires == keys:
+		meta["suggested_config"] = "maxmemory-policy volatile-lru"
+		return Finding{
+			ID:           FindingEvictionPolicyMismatch,
+			Severity:     SeverityLow,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("noeviction on a cache workload where all %d keys have a TTL; writes fail instead of evicting", keys),
+			Metadata:     meta,
+		}, true
+	}
+	return Finding{}, false
+}
+
+// persistenceEnabled reports whether RDB snapshots or AOF are configured.
+func persistenceEnabled(ctx context.Context, client RedisClient, cfg AuditConfig) (bool, error) {
+	save, appendonly, err := persistenceConfig(ctx, client, cfg)
+	if err != nil {
+		return false, err
+	}
+	return save != "" || appendonly == "yes", nil
+}
+
+// storm reports evictions running above the threshold during the sample
+// window.
+func (s *EvictionScanner) storm(cfg AuditConfig, policy string, usedMemory, maxMemory int64) (Finding, bool) {
+	perSec := cfg.window.PerSecond("evicted_keys")
+	if perSec < evictionStormPerSec {
+		return Finding{}, false
+	}
+	severity := SeverityMedium
+	if perSec >= evictionStormHighPerSec {
+		severity = SeverityHigh
+	}
+	window := cfg.window.Duration()
+	meta := map[string]any{
+		"evictions_per_sec":      perSec,
+		"peak_evictions_per_sec": cfg.window.PeakPerSecond("evicted_keys"),
+		"ops_per_sec":            cfg.window.PerSecond("total_commands_processed"),
+		"window_seconds":         window.Seconds(),
+		"policy":                 policy,
+		"used_memory":            usedMemory,
+		"maxmemory":              maxMemory,
+		"recommendation":         "find the writer filling memory, or raise maxmemory if the working set no longer fits",
+	}
+	return Finding{
+		ID:           FindingEvictionStorm,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("%.0f keys evicted/sec over %s (%s policy, used: %s, max: %s)",
+			perSec, window.Round(time.Second), policy, FormatBytes(usedMemory), FormatBytes(maxMemory)),
+		Metadata: meta,
+	}, true
+}
@@ -66,14 +66,168 @@ func TestEvictionScanner_NoRiskWithEvictionPolicy(t *testing.T) {
 func TestEvictionScanner_NoMaxmemory(t *testing.T) {
 	mock := newMockClient()
 	mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": "noeviction"}
-	mock.infoResponses["memory"] = "# Memory\nused_memory:950000\nmaxmemory:0\n"
+	mock.configValues["save"] = map[string]string{"save": "3600 1"}
+	mock.infoResponses["memory"] = "# Memory\nused_memory:950000\nmaxmemory:0\ntotal_system_memory:8589934592\n"
 
 	s := &EvictionScanner{}
 	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if len(findings) != 0 {
-		t.Errorf("expected 0 findings (maxmemory=0 means unlimited), got %d", len(findings))
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding (maxmemory=0 means unlimited), got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != FindingMaxmemoryNotSet || f.Severity != SeverityHigh {
+		t.Errorf("expected high MAXMEMORY_NOT_SET, got %s %s", f.Severity, f.ID)
+	}
+	// Persistence is on, so half of the 8 GB system memory is suggested.
+	if f.Metadata["suggested_config"] != "maxmemory 4096mb" {
+		t.Errorf("unexpected suggestion: %v", f.Metadata["suggested_config"])
+	}
+}
+
+func TestEvictionScanner_Overcommit(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxmemory   string
+		save        string
+		want        Severity
+		wantSuggest string
+	}{
+		{"exceeds system memory", "10737418240", "", SeverityCritical, "maxmemory 6144mb"},
+		{"no fork headroom", "6442450944", "3600 1", SeverityHigh, "maxmemory 4096mb"},
+		{"fits without persistence", "6442450944", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": "allkeys-lru"}
+			mock.configValues["save"] = map[string]string{"save": tt.save}
+			mock.infoResponses["memory"] = "used_memory:1000\nused_memory_rss:4294967296\nmaxmemory:" + tt.maxmemory + "\ntotal_system_memory:8589934592\n"
+
+			s := &EvictionScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("expected 0 findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].ID != FindingMaxmemoryOvercommit {
+				t.Fatalf("expected 1 MAXMEMORY_OVERCOMMIT finding, got %+v", findings)
+			}
+			if findings[0].Severity != tt.want {
+				t.Errorf("expected severity %q, got %q", tt.want, findings[0].Severity)
+			}
+			if findings[0].Metadata["suggested_config"] != tt.wantSuggest {
+				t.Errorf("expected %q, got %v", tt.wantSuggest, findings[0].Metadata["suggested_config"])
+			}
+		})
+	}
+}
+
+func TestEvictionScanner_Oversized(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": "allkeys-lru"}
+	// 16 GB limit, 512 MB resident, 600 MB peak.
+	mock.infoResponses["memory"] = "used_memory:500000000\nused_memory_rss:536870912\nused_memory_peak:629145600\nmaxmemory:17179869184\n"
+
+	s := &EvictionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingMaxmemoryOversized {
+		t.Fatalf("expected 1 MAXMEMORY_OVERSIZED finding, got %+v", findings)
+	}
+	if findings[0].Severity != SeverityLow {
+		t.Errorf("expected severity low, got %q", findings[0].Severity)
+	}
+	if findings[0].Metadata["suggested_config"] != "maxmemory 1200mb" {
+		t.Errorf("unexpected suggestion: %v", findings[0].Metadata["suggested_config"])
+	}
+}
+
+func TestEvictionScanner_PolicyMismatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		keyspace    string
+		want        Severity
+		wantSuggest string
+	}{
+		{"volatile without TTLs", "volatile-lru", "db0:keys=1000,expires=0,avg_ttl=0", SeverityHigh, "maxmemory-policy allkeys-lru"},
+		{"volatile with few TTLs", "volatile-lfu", "db0:keys=1000,expires=20,avg_ttl=0", SeverityMedium, "maxmemory-policy allkeys-lfu"},
+		{"noeviction cache", "noeviction", "db0:keys=1000,expires=1000,avg_ttl=5000", SeverityLow, "maxmemory-policy volatile-lru"},
+		{"volatile with TTLs", "volatile-lru", "db0:keys=1000,expires=900,avg_ttl=5000", "", ""},
+		{"allkeys", "allkeys-lru", "db0:keys=1000,expires=0,avg_ttl=0", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": tt.policy}
+			mock.infoResponses["memory"] = "used_memory:100000\nmaxmemory:1000000\n"
+			mock.infoResponses["keyspace"] = "# Keyspace\n" + tt.keyspace + "\n"
+
+			s := &EvictionScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("expected 0 findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].ID != FindingEvictionPolicyMismatch {
+				t.Fatalf("expected 1 EVICTION_POLICY_MISMATCH finding, got %+v", findings)
+			}
+			if findings[0].Severity != tt.want {
+				t.Errorf("expected severity %q, got %q", tt.want, findings[0].Severity)
+			}
+			if findings[0].Metadata["suggested_config"] != tt.wantSuggest {
+				t.Errorf("expected %q, got %v", tt.wantSuggest, findings[0].Metadata["suggested_config"])
+			}
+		})
+	}
+}
+
+func TestEvictionScanner_Storm(t *testing.T) {
+	tests := []struct {
+		name    string
+		evicted int64
+		want    Severity
+	}{
+		{"quiet", 100, ""},
+		{"storm", 6000, SeverityMedium},
+		{"severe storm", 60000, SeverityHigh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": "allkeys-lru"}
+			mock.infoResponses["memory"] = "# Memory\nused_memory:1000000\nmaxmemory:1000000\n"
+
+			s := &EvictionScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{window: windowOf("evicted_keys", 0, tt.evicted)})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := findingsByID(findings)[FindingEvictionStorm]
+			if tt.want == "" {
+				if len(got) != 0 {
+					t.Errorf("expected no EVICTION_STORM, got %+v", got)
+				}
+				return
+			}
+			if len(got) != 1 || got[0].Severity != tt.want {
+				t.Errorf("expected %s EVICTION_STORM, got %+v", tt.want, got)
+			}
+		})
 	}
 }
@@ -0,0 +1,296 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// formatSniffBytes is how much of each value is read to classify it.
+const formatSniffBytes = 32
+
+// Value formats recognized by detectValueFormat.
+const (
+	FormatJSON     = "json"
+	FormatMsgPack  = "msgpack"
+	FormatProtobuf = "protobuf"
+	FormatJava     = "java_serialized"
+	FormatPickle   = "python_pickle"
+	FormatGzip     = "gzip"
+	FormatZstd     = "zstd"
+	FormatSnappy   = "snappy"
+	FormatInteger  = "integer"
+	FormatText     = "text"
+	FormatBinary   = "binary"
+	FormatEmpty    = "empty"
+)
+
+// unsafeFormats deserialize into arbitrary objects and allow code execution
+// when an attacker can write to the key.
+var unsafeFormats = map[string]bool{
+	FormatJava:   true,
+	FormatPickle: true,
+}
+
+var (
+	magicGzip   = []byte{0x1f, 0x8b}
+	magicZstd   = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	magicSnappy = []byte{0xff, 0x06, 0x00, 0x00, 's', 'N', 'a', 'P', 'p', 'Y'}
+	magicJava   = []byte{0xac, 0xed, 0x00, 0x05}
+)
+
+// FormatScanner classifies sampled string values by serialization format
+// from their first bytes, records the format mix per namespace in the
+// inventory, and reports formats that are unsafe to deserialize.
+type FormatScanner struct{}
+
+func (s *FormatScanner) Name() string { return "formats" }
+
+type formatStats struct {
+	counts map[string]int
+	// unsafeKeys holds example keys per unsafe format.
+	unsafeKeys map[string][]string
+}
+
+func (s *FormatScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	budget := newValueBudget(cfg.ValueBudget)
+	stats := make(map[string]*formatStats)
+
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		keyType, err := client.Type(ctx, key)
+		if err != nil || keyType != "string" {
+			return true
+		}
+		length, err := client.StrLen(ctx, key)
+		if err != nil {
+			return true
+		}
+		n := min(length, formatSniffBytes)
+		if !budget.take(n) {
+			return false
+		}
+		var prefix []byte
+		if n > 0 {
+			head, err := client.GetRange(ctx, key, 0, n-1)
+			if err != nil {
+				return true
+			}
+			prefix = []byte(head)
+		}
+
+		format := detectValueFormat(prefix, length)
+		ns := keyNamespace(key)
+		st, ok := stats[ns]
+		if !ok {
+			st = &formatStats{counts: make(map[string]int), unsafeKeys: make(map[string][]string)}
+			stats[ns] = st
+		}
+		st.counts[format]++
+		if unsafeFormats[format] && len(st.unsafeKeys[format]) < 5 {
+			st.unsafeKeys[format] = append(st.unsafeKeys[format], key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0, len(stats))
+	for ns := range stats {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	mix := make(map[string]any, len(namespaces))
+	var findings []Finding
+	for _, ns := range namespaces {
+		st := stats[ns]
+		mix[ns] = formatMix(st)
+
+		for _, format := range sortedFormats(st.counts) {
+			if !unsafeFormats[format] {
+				continue
+			}
+			findings = append(findings, Finding{
+				ID:           FindingUnsafeSerialization,
+				Severity:     SeverityHigh,
+				ResourceType: "Namespace",
+				ResourceID:   ns,
+				Message: fmt.Sprintf("namespace %q stores %d %s value(s); deserializing them can execute code if an attacker can write to these keys",
+					ns, st.counts[format], format),
+				Metadata: map[string]any{
+					"namespace":    ns,
+					"format":       format,
+					"count":        st.counts[format],
+					"example_keys": st.unsafeKeys[format],
+				},
+			})
+		}
+	}
+	cfg.inventory.set("value_formats", mix)
+
+	return findings, nil
+}
+
+// formatMix summarizes a namespace's sampled formats for the inventory.
+func formatMix(st *formatStats) map[string]any {
+	total := 0
+	for _, c := range st.counts {
+		total += c
+	}
+	formats := sortedFormats(st.counts)
+	dominant := formats[0]
+
+	percent := make(map[string]float64, len(st.counts))
+	for format, c := range st.counts {
+		percent[format] = float64(c) / float64(total) * 100
+	}
+
+	return map[string]any{
+		"values_sampled":  total,
+		"formats":         st.counts,
+		"formats_percent": percent,
+		"dominant_format": dominant,
+	}
+}
+
+// sortedFormats returns format names ordered by count, most common first.
+func sortedFormats(counts map[string]int) []string {
+	formats := make([]string, 0, len(counts))
+	for f := range counts {
+		formats = append(formats, f)
+	}
+	sort.Slice(formats, func(i, j int) bool {
+		if counts[formats[i]] != counts[formats[j]] {
+			return counts[formats[i]] > counts[formats[j]]
+		}
+		return formats[i] < formats[j]
+	})
+	return formats
+}
+
+// detectValueFormat classifies a value from its first bytes. length is the
+// full value length, which tells whether prefix is the whole value.
+func detectValueFormat(prefix []byte, length int64) string {
+	if length == 0 || len(prefix) == 0 {
+		return FormatEmpty
+	}
+
+	switch {
+	case bytes.HasPrefix(prefix, magicGzip):
+		return FormatGzip
+	case bytes.HasPrefix(prefix, magicZstd):
+		return FormatZstd
+	case bytes.HasPrefix(prefix, magicSnappy):
+		return FormatSnappy
+	case bytes.HasPrefix(prefix, magicJava):
+		return FormatJava
+	case len(prefix) >= 2 && prefix[0] == 0x80 && prefix[1] >= 2 && prefix[1] <= 5:
+		// PROTO opcode followed by protocol version 2-5.
+		return FormatPickle
+	case isLegacyPickle(prefix):
+		return FormatPickle
+	}
+
+	if int64(len(prefix)) == length {
+		if _, err := strconv.ParseInt(string(prefix), 10, 64); err == nil {
+			return FormatInteger
+		}
+	}
+
+	text := isText(prefix, int64(len(prefix)) < length)
+	if text {
+		trimmed := bytes.TrimLeft(prefix, " \t\r\n")
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+			return FormatJSON
+		}
+		return FormatText
+	}
+
+	b := prefix[0]
+	switch {
+	case b >= 0x80 && b <= 0x9f, b >= 0xdc && b <= 0xdf:
+		// fixmap, fixarray, array16/32, map16/32
+		return FormatMsgPack
+	case isProtobufTag(b):
+		return FormatProtobuf
+	}
+	return FormatBinary
+}
+
+// isLegacyPickle reports whether prefix starts like a protocol 0 or 1
+// pickle, which has no PROTO header. These are the Python 2 default and
+// common in legacy caches:
+//
+//	(dp0\n  (lp0\n          MARK, DICT or LIST, PUT (protocol 0)
+//	}q\x00  ]q\x00          EMPTY_DICT or EMPTY_LIST, BINPUT (protocol 1)
+//	cmodule\nname\n         GLOBAL, followed by PUT, BINPUT, or MARK
+func isLegacyPickle(prefix []byte) bool {
+	if len(prefix) >= 3 && (prefix[0] == '}' || prefix[0] == ']') && (prefix[1] == 'q' || prefix[1] == 'r') {
+		return true
+	}
+	if len(prefix) >= 5 && prefix[0] == '(' && (prefix[1] == 'd' || prefix[1] == 'l') && prefix[2] == 'p' {
+		digits := 0
+		for _, b := range prefix[3:] {
+			if b == '\n' {
+				return digits > 0
+			}
+			if b < '0' || b > '9' {
+				return false
+			}
+			digits++
+		}
+		return false
+	}
+	if prefix[0] == 'c' {
+		rest := prefix[1:]
+		for i := 0; i < 2; i++ {
+			end := bytes.IndexByte(rest, '\n')
+			if end <= 0 || !isPickleIdentifier(rest[:end]) {
+				return false
+			}
+			rest = rest[end+1:]
+		}
+		return len(rest) > 0 && bytes.IndexByte([]byte("pq("), rest[0]) >= 0
+	}
+	return false
+}
+
+// isPickleIdentifier reports whether name is a dotted Python identifier, as
+// in the module and name of a GLOBAL opcode.
+func isPickleIdentifier(name []byte) bool {
+	for _, b := range name {
+		if !(b == '_' || b == '.' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// isProtobufTag reports whether b is a plausible first field tag: a small
+// field number with a varint, fixed64, length-delimited, or fixed32 wire type.
+func isProtobufTag(b byte) bool {
+	field := b >> 3
+	wireType := b & 0x07
+	return field >= 1 && field <= 15 && (wireType == 0 || wireType == 1 || wireType == 2 || wireType == 5)
+}
+
+// isText reports whether data is printable UTF-8. A truncated prefix may end
+// mid-rune, so an incomplete trailing sequence is tolerated.
+func isText(data []byte, truncated bool) bool {
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size <= 1 {
+			return truncated && len(data) < utf8.UTFMax && !utf8.FullRune(data)
+		}
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+		data = data[size:]
+	}
+	return true
+}
@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFormatScanner_Name(t *testing.T) {
+	s := &FormatScanner{}
+	if s.Name() != "formats" {
+		t.Errorf("expected name 'formats', got %q", s.Name())
+	}
+}
+
+func TestDetectValueFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  string
+		length int64
+		want   string
+	}{
+		{"json object", `{"id":1}`, 8, FormatJSON},
+		{"json array with space", ` [1,2,3]`, 8, FormatJSON},
+		{"integer", "12345", 5, FormatInteger},
+		{"negative integer", "-42", 3, FormatInteger},
+		{"digits prefix of longer value", "12345", 50, FormatText},
+		{"plain text", "hello world", 11, FormatText},
+		{"gzip", "\x1f\x8b\x08\x00", 100, FormatGzip},
+		{"zstd", "\x28\xb5\x2f\xfd\x00", 100, FormatZstd},
+		{"snappy framed", "\xff\x06\x00\x00sNaPpY", 100, FormatSnappy},
+		{"java", "\xac\xed\x00\x05sr", 100, FormatJava},
+		{"pickle", "\x80\x04\x95\x10", 100, FormatPickle},
+		{"pickle protocol 0 dict", "(dp0\nS'id'\np1\nI1\ns.", 19, FormatPickle},
+		{"pickle protocol 0 list", "(lp1\nI1\na.", 11, FormatPickle},
+		{"pickle protocol 1 dict", "}q\x00U\x02idq\x01K\x01s.", 14, FormatPickle},
+		{"pickle protocol 1 list", "]q\x01(K\x01e.", 9, FormatPickle},
+		{"pickle global", "ccopy_reg\n_reconstructor\np0\n(c__main__\nUser", 100, FormatPickle},
+		{"text with newlines", "cat\ndog\nbird", 12, FormatText},
+		{"text in parentheses", "(dp is short)", 13, FormatText},
+		{"msgpack fixmap", "\x82\xa2id\x01", 20, FormatMsgPack},
+		{"msgpack map16", "\xde\x00\x10", 200, FormatMsgPack},
+		{"protobuf", "\x0a\x05hello\x10\x01", 9, FormatProtobuf},
+		{"binary", "\x00\x00\x00\x01", 4, FormatBinary},
+		{"empty", "", 0, FormatEmpty},
+		{"truncated utf8", "caf\xc3", 10, FormatText},
+	}
+	for _, tt := range tests {
+		if got := detectValueFormat([]byte(tt.value), tt.length); got != tt.want {
+			t.Errorf("%s: detectValueFormat(%q) = %q, want %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestFormatScanner_MixAndUnsafe(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"api:1", "api:2", "api:3", "jobs:1", "counter:1"}
+	mock.values = map[string]string{
+		"api:1":     `{"a":1}`,
+		"api:2":     `{"b":2}`,
+		"api:3":     "plain",
+		"jobs:1":    "\x80\x04\x95\x20\x00\x00",
+		"counter:1": "7",
+	}
+	cfg := AuditConfig{inventory: &inventory{data: make(map[string]any)}}
+
+	s := &FormatScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(findings) != 1 {
+		t.Fatalf("expected only the unsafe serialization finding, got %v", findings)
+	}
+	f := findings[0]
+	if f.ID != FindingUnsafeSerialization || f.ResourceID != "jobs" || f.Severity != SeverityHigh {
+		t.Errorf("expected high UNSAFE_SERIALIZATION for 'jobs', got %s %s %s", f.Severity, f.ID, f.ResourceID)
+	}
+
+	mix, ok := cfg.inventory.data["value_formats"].(map[string]any)
+	if !ok || len(mix) != 3 {
+		t.Fatalf("expected the format mix of 3 namespaces in the inventory, got %v", cfg.inventory.data["value_formats"])
+	}
+	if api := mix["api"].(map[string]any); api["dominant_format"] != FormatJSON {
+		t.Errorf("expected api dominant format json, got %v", api["dominant_format"])
+	}
+}
+
+func TestFormatScanner_UnsafeExamplesPerFormat(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"jobs:1", "jobs:2", "jobs:3"}
+	mock.values = map[string]string{
+		"jobs:1": "\x80\x04\x95\x20\x00\x00",
+		"jobs:2": "\xac\xed\x00\x05sr",
+		"jobs:3": "\x80\x04\x95\x20\x00\x00",
+	}
+
+	findings, err := (&FormatScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	unsafe := findingsByID(findings)[FindingUnsafeSerialization]
+	if len(unsafe) != 2 {
+		t.Fatalf("expected pickle and Java findings, got %v", unsafe)
+	}
+	want := map[string][]string{
+		FormatPickle: {"jobs:1", "jobs:3"},
+		FormatJava:   {"jobs:2"},
+	}
+	for _, f := range unsafe {
+		format := f.Metadata["format"].(string)
+		got := f.Metadata["example_keys"].([]string)
+		if strings.Join(got, ",") != strings.Join(want[format], ",") {
+			t.Errorf("%s: expected example keys %v, got %v", format, want[format], got)
+		}
+	}
+}
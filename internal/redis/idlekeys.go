@@ -3,14 +3,29 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
 	"time"
 )
 
-// IdleKeyScanner audits Redis keys for inactivity using OBJECT IDLETIME.
+// maxIdleExamples caps the example keys kept per namespace when OBJECT FREQ
+// covers less than the idle threshold.
+const maxIdleExamples = 5
+
+// IdleKeyScanner audits Redis keys for inactivity using OBJECT IDLETIME, or
+// OBJECT FREQ under an LFU policy where idle time is not tracked.
 type IdleKeyScanner struct{}
 
 func (s *IdleKeyScanner) Name() string { return "idle_keys" }
 
+// Applicable requires OBJECT IDLETIME or, under LFU, OBJECT FREQ.
+func (s *IdleKeyScanner) Applicable(p *Profile) (string, bool) {
+	if p.Supports(CapObjectIdletime) || p.Supports(CapObjectFreq) {
+		return "", true
+	}
+	return p.Reason(CapObjectIdletime), false
+}
+
 func (s *IdleKeyScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
 	var findings []Finding
 
@@ -19,56 +34,162 @@ func (s *IdleKeyScanner) Audit(ctx context.Context, client RedisClient, cfg Audi
 		idleDays = 30
 	}
 
-	sampleSize := cfg.SampleSize
-	if sampleSize <= 0 {
-		sampleSize = 10000
+	threshold := time.Duration(idleDays) * 24 * time.Hour
+
+	if !cfg.supports(CapObjectIdletime) {
+		return s.auditFreq(ctx, client, cfg, threshold)
 	}
 
-	threshold := time.Duration(idleDays) * 24 * time.Hour
-	sampled := 0
-	var cursor uint64
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		idle, err := client.ObjectIdleTime(ctx, key)
+		if err != nil {
+			return true
+		}
 
-	for sampled < sampleSize {
-		batchSize := int64(100)
-		if remaining := sampleSize - sampled; remaining < int(batchSize) {
-			batchSize = int64(remaining)
+		if idle >= threshold {
+			idleDaysActual := int(idle.Hours() / 24)
+			findings = append(findings, Finding{
+				ID:           FindingIdleKey,
+				Severity:     SeverityMedium,
+				ResourceType: "Key",
+				ResourceID:   key,
+				Message:      fmt.Sprintf("key %q idle for %d days (threshold: %d days)", key, idleDaysActual, idleDays),
+				Metadata: map[string]any{
+					"key":            key,
+					"idle_seconds":   int64(idle.Seconds()),
+					"idle_days":      idleDaysActual,
+					"threshold_days": idleDays,
+				},
+			})
 		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		keys, nextCursor, err := client.Scan(ctx, cursor, "*", batchSize)
-		if err != nil {
-			return nil, fmt.Errorf("scan keys: %w", err)
+	return findings, nil
+}
+
+// auditFreq reports keys whose LFU counter has decayed to zero. The counter
+// drops by one every lfu-decay-time minutes a key goes unread, and a key read
+// once may hold a counter of 1, so zero only proves the key was idle for one
+// decay period. When that period covers the idle threshold, each key is
+// reported; otherwise, as under the 1-minute default, the keys are counted
+// per namespace and reported with the window they actually prove.
+func (s *IdleKeyScanner) auditFreq(ctx context.Context, client RedisClient, cfg AuditConfig, threshold time.Duration) ([]Finding, error) {
+	if !cfg.supports(CapConfig) {
+		cfg.skip(s.Name(), "idle_keys", CapConfig)
+		return nil, nil
+	}
+	conf, err := client.ConfigGet(ctx, "lfu-decay-time")
+	if err != nil {
+		return nil, fmt.Errorf("config get lfu-decay-time: %w", err)
+	}
+	decayMinutes, _ := strconv.ParseInt(conf["lfu-decay-time"], 10, 64)
+	decay := time.Duration(decayMinutes) * time.Minute
+	if decay < threshold {
+		return s.auditShortDecay(ctx, client, cfg, decayMinutes, threshold)
+	}
+
+	idleDays := int(threshold.Hours() / 24)
+	var findings []Finding
+	err = forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		freq, err := client.ObjectFreq(ctx, key)
+		if err != nil || freq > 0 {
+			return true
 		}
+		findings = append(findings, Finding{
+			ID:           FindingIdleKey,
+			Severity:     SeverityLow,
+			ResourceType: "Key",
+			ResourceID:   key,
+			Message:      fmt.Sprintf("key %q has an LFU access frequency of 0, idle for at least %d days (lfu-decay-time %d min)", key, idleDays, decayMinutes),
+			Metadata: map[string]any{
+				"key":            key,
+				"lfu_freq":       freq,
+				"lfu_decay_time": decayMinutes,
+				"threshold_days": idleDays,
+				"source":         "object_freq",
+			},
+		})
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
 
-		for _, key := range keys {
-			idle, err := client.ObjectIdleTime(ctx, key)
-			if err != nil {
-				continue
-			}
+// freqIdleGroup counts sampled keys with an LFU frequency of 0 in a namespace.
+type freqIdleGroup struct {
+	sampled  int
+	idle     int
+	examples []string
+}
 
-			if idle >= threshold {
-				idleDaysActual := int(idle.Hours() / 24)
-				findings = append(findings, Finding{
-					ID:           FindingIdleKey,
-					Severity:     SeverityMedium,
-					ResourceType: "Key",
-					ResourceID:   key,
-					Message:      fmt.Sprintf("key %q idle for %d days (threshold: %d days)", key, idleDaysActual, idleDays),
-					Metadata: map[string]any{
-						"key":            key,
-						"idle_seconds":   int64(idle.Seconds()),
-						"idle_days":      idleDaysActual,
-						"threshold_days": idleDays,
-					},
-				})
+// auditShortDecay reports, per namespace, the sampled keys with an LFU
+// frequency of 0 when lfu-decay-time is shorter than the idle threshold. A
+// frequency of 0 then only shows a key went unread for lfu-decay-time, so the
+// findings are low confidence and state that window.
+func (s *IdleKeyScanner) auditShortDecay(ctx context.Context, client RedisClient, cfg AuditConfig, decayMinutes int64, threshold time.Duration) ([]Finding, error) {
+	groups := make(map[string]*freqIdleGroup)
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		freq, err := client.ObjectFreq(ctx, key)
+		if err != nil {
+			return true
+		}
+		ns := keyNamespace(key)
+		g, ok := groups[ns]
+		if !ok {
+			g = &freqIdleGroup{}
+			groups[ns] = g
+		}
+		g.sampled++
+		if freq == 0 {
+			g.idle++
+			if len(g.examples) < maxIdleExamples {
+				g.examples = append(g.examples, key)
 			}
 		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		sampled += len(keys)
-		cursor = nextCursor
-		if cursor == 0 {
-			break
+	namespaces := make([]string, 0, len(groups))
+	for ns, g := range groups {
+		if g.idle > 0 {
+			namespaces = append(namespaces, ns)
 		}
 	}
+	sort.Strings(namespaces)
 
+	idleDays := int(threshold.Hours() / 24)
+	window := time.Duration(decayMinutes) * time.Minute
+	findings := make([]Finding, 0, len(namespaces))
+	for _, ns := range namespaces {
+		g := groups[ns]
+		findings = append(findings, Finding{
+			ID:           FindingIdleKey,
+			Severity:     SeverityLow,
+			ResourceType: "Namespace",
+			ResourceID:   ns,
+			Message: fmt.Sprintf("%d of %d sampled keys in namespace %q have an LFU access frequency of 0: unread for at least %s (lfu-decay-time), which does not show %d days idle",
+				g.idle, g.sampled, ns, window, idleDays),
+			Metadata: map[string]any{
+				"namespace":      ns,
+				"idle_keys":      g.idle,
+				"keys_sampled":   g.sampled,
+				"example_keys":   g.examples,
+				"lfu_decay_time": decayMinutes,
+				"threshold_days": idleDays,
+				"confidence":     "low",
+				"source":         "object_freq",
+				"recommendation": fmt.Sprintf("raise lfu-decay-time to %d minutes or more for OBJECT FREQ to show %d days of idleness", int64(threshold.Minutes()), idleDays),
+			},
+		})
+	}
 	return findings, nil
 }
@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -88,3 +90,73 @@ func TestIdleKeyScanner_DefaultIdleDays(t *testing.T) {
 		t.Errorf("expected 1 finding with default 30-day threshold, got %d", len(findings))
 	}
 }
+
+func TestIdleKeyScanner_LFUFallback(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"hot", "cold"}
+	mock.idleTimeErr = errors.New("ERR An LFU maxmemory policy is selected, idle time not tracked")
+	mock.freqs = map[string]int64{"hot": 12, "cold": 0}
+	// A 30-day decay period covers the default idle threshold.
+	mock.configValues["lfu-decay-time"] = map[string]string{"lfu-decay-time": "43200"}
+	cfg := AuditConfig{Profile: &Profile{Unavailable: map[Capability]string{CapObjectIdletime: "LFU policy"}}}
+
+	s := &IdleKeyScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ResourceID != "cold" {
+		t.Fatalf("expected the cold key, got %+v", findings)
+	}
+	if findings[0].Severity != SeverityLow || findings[0].Metadata["source"] != "object_freq" {
+		t.Errorf("expected a low-severity OBJECT FREQ finding, got %+v", findings[0])
+	}
+}
+
+func TestIdleKeyScanner_LFUShortDecay(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"cache:1", "cache:2", "cache:3", "hot:1"}
+	mock.freqs = map[string]int64{"cache:1": 0, "cache:2": 0, "cache:3": 4, "hot:1": 20}
+	mock.configValues["lfu-decay-time"] = map[string]string{"lfu-decay-time": "1"}
+	cfg := AuditConfig{
+		Profile: &Profile{Unavailable: map[Capability]string{CapObjectIdletime: "LFU policy"}},
+		skipped: &skipList{},
+	}
+
+	findings, err := (&IdleKeyScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected one namespace finding under the default decay time, got %+v", findings)
+	}
+	f := findings[0]
+	if f.ResourceID != "cache" || f.Severity != SeverityLow || f.Metadata["idle_keys"] != 2 || f.Metadata["keys_sampled"] != 3 {
+		t.Errorf("unexpected finding: %s %s %v", f.ResourceID, f.Severity, f.Metadata)
+	}
+	if f.Metadata["confidence"] != "low" || !strings.Contains(f.Message, "at least 1m0s") {
+		t.Errorf("expected a low-confidence finding stating the 1-minute window, got %q %v", f.Message, f.Metadata)
+	}
+	if len(cfg.skipped.checks) != 0 {
+		t.Errorf("expected no skipped checks, got %v", cfg.skipped.checks)
+	}
+
+	cfg.Profile.Unavailable[CapConfig] = "blocked"
+	if _, err := (&IdleKeyScanner{}).Audit(context.Background(), mock, cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(cfg.skipped.checks) != 1 || cfg.skipped.checks[0].Reason != "blocked" {
+		t.Errorf("expected idle_keys to be skipped without CONFIG, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestIdleKeyScanner_Applicable(t *testing.T) {
+	s := &IdleKeyScanner{}
+	neither := &Profile{Unavailable: map[Capability]string{CapObjectIdletime: "no", CapObjectFreq: "no"}}
+	if _, ok := s.Applicable(neither); ok {
+		t.Error("expected the auditor not to apply without OBJECT IDLETIME or OBJECT FREQ")
+	}
+	if _, ok := s.Applicable(nil); !ok {
+		t.Error("expected the auditor to apply without a profile")
+	}
+}
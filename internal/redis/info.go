@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -20,3 +21,42 @@ func ParseInfo(raw string) map[string]string {
 	}
 	return result
 }
+
+// ParseInfoFields parses a compound INFO value such as
+// "ip=10.0.0.2,port=6379,state=online" into a key-value map.
+func ParseInfoFields(value string) map[string]string {
+	result := make(map[string]string)
+	for _, part := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(part, "=")
+		if ok {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+// infoInt returns an integer INFO field, or 0 if it is missing or malformed.
+func infoInt(info map[string]string, key string) int64 {
+	v, _ := strconv.ParseInt(info[key], 10, 64)
+	return v
+}
+
+// infoFloat returns a float INFO field, or 0 if it is missing or malformed.
+func infoFloat(info map[string]string, key string) float64 {
+	v, _ := strconv.ParseFloat(info[key], 64)
+	return v
+}
+
+// keyspaceTotals sums the keys and expires counts of every database in an
+// INFO keyspace map ("db0:keys=100,expires=10,avg_ttl=0").
+func keyspaceTotals(info map[string]string) (keys, expires int64) {
+	for name, value := range info {
+		if !strings.HasPrefix(name, "db") {
+			continue
+		}
+		fields := ParseInfoFields(value)
+		keys += infoInt(fields, "keys")
+		expires += infoInt(fields, "expires")
+	}
+	return keys, expires
+}
@@ -51,3 +51,31 @@ func TestParseInfoCommentsOnly(t *testing.T) {
 		t.Errorf("ParseInfo comments-only should return empty map, got %d entries", len(info))
 	}
 }
+
+func TestParseInfoFields(t *testing.T) {
+	fields := ParseInfoFields("ip=10.0.0.2,port=6379,state=online,offset=12345,lag=0")
+	if fields["ip"] != "10.0.0.2" || fields["state"] != "online" || fields["offset"] != "12345" {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	if len(ParseInfoFields("")) != 0 {
+		t.Error("expected empty map for empty value")
+	}
+}
+
+func TestInfoNumbers(t *testing.T) {
+	info := map[string]string{"n": "42", "f": "1.5", "bad": "x"}
+	if infoInt(info, "n") != 42 || infoInt(info, "bad") != 0 || infoInt(info, "missing") != 0 {
+		t.Error("unexpected infoInt results")
+	}
+	if infoFloat(info, "f") != 1.5 || infoFloat(info, "bad") != 0 {
+		t.Error("unexpected infoFloat results")
+	}
+}
+
+func TestKeyspaceTotals(t *testing.T) {
+	info := ParseInfo("# Keyspace\r\ndb0:keys=100,expires=10,avg_ttl=0\r\ndb3:keys=50,expires=50,avg_ttl=1000\r\n")
+	keys, expires := keyspaceTotals(info)
+	if keys != 150 || expires != 60 {
+		t.Errorf("expected 150 keys and 60 expires, got %d and %d", keys, expires)
+	}
+}
@@ -0,0 +1,29 @@
+package redis
+
+import "sync"
+
+// inventory collects data that belongs in the report but is not a finding,
+// such as parsed ACL users or command tables. Auditors record into it through
+// AuditConfig; it is nil when an auditor runs outside MultiAuditor.
+type inventory struct {
+	mu   sync.Mutex
+	data map[string]any
+}
+
+func (inv *inventory) set(key string, value any) {
+	if inv == nil {
+		return
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	inv.data[key] = value
+}
+
+func (inv *inventory) get(key string) any {
+	if inv == nil {
+		return nil
+	}
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	return inv.data[key]
+}
@@ -0,0 +1,146 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultLatencyThreshold is the event latency reported when no
+	// threshold is configured for the event.
+	defaultLatencyThreshold = 100 * time.Millisecond
+	// latencyHighThreshold raises a latency spike to high severity.
+	latencyHighThreshold = time.Second
+	// suggestedLatencyMonitorMs is recommended when the monitor is off.
+	suggestedLatencyMonitorMs = 100
+)
+
+// LatencyScanner audits the latency monitor: whether it is enabled and which
+// events (fork, expire-cycle, eviction-cycle, aof-fsync-always, ...) stalled
+// the server beyond their thresholds.
+type LatencyScanner struct{}
+
+func (s *LatencyScanner) Name() string { return "latency" }
+
+// Applicable requires the LATENCY commands (Redis 2.8.13+).
+func (s *LatencyScanner) Applicable(p *Profile) (string, bool) { return p.require(CapLatency) }
+
+func (s *LatencyScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	var findings []Finding
+	monitorMs := int64(-1)
+	if cfg.supports(CapConfig) {
+		monitorConfig, err := client.ConfigGet(ctx, "latency-monitor-threshold")
+		if err != nil {
+			return nil, fmt.Errorf("config get latency-monitor-threshold: %w", err)
+		}
+		monitorMs, _ = strconv.ParseInt(monitorConfig["latency-monitor-threshold"], 10, 64)
+	} else {
+		cfg.skip(s.Name(), string(FindingLatencyMonitorOff), CapConfig)
+	}
+
+	if monitorMs == 0 {
+		findings = append(findings, Finding{
+			ID:           FindingLatencyMonitorOff,
+			Severity:     SeverityLow,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      "latency monitor is disabled; fork, expire, and eviction stalls are not recorded",
+			Metadata: map[string]any{
+				"latency-monitor-threshold": 0,
+				"suggested_config":          fmt.Sprintf("latency-monitor-threshold %d", suggestedLatencyMonitorMs),
+			},
+		})
+	}
+
+	events, err := client.LatencyLatest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("latency latest: %w", err)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Max > events[j].Max })
+
+	var spikes []Finding
+	for _, e := range events {
+		threshold := latencyThreshold(cfg, e.Name)
+		if e.Max < threshold {
+			continue
+		}
+		history, err := client.LatencyHistory(ctx, e.Name)
+		if err != nil {
+			return nil, fmt.Errorf("latency history %s: %w", e.Name, err)
+		}
+		spikes = append(spikes, latencySpike(cfg, e, history, threshold))
+	}
+
+	if len(spikes) > 0 {
+		// LATENCY DOCTOR is advisory; servers that lack it or deny it to
+		// this user still get the spike findings.
+		if doctor, err := client.LatencyDoctor(ctx); err == nil && doctor != "" {
+			for _, f := range spikes {
+				f.Metadata["latency_doctor"] = doctor
+			}
+		}
+	}
+
+	return append(findings, spikes...), nil
+}
+
+// latencyThreshold returns the configured threshold for event, falling back
+// to the global threshold and then the default.
+func latencyThreshold(cfg AuditConfig, event string) time.Duration {
+	if t, ok := cfg.LatencyEventThresholds[event]; ok && t > 0 {
+		return t
+	}
+	if cfg.LatencyThreshold > 0 {
+		return cfg.LatencyThreshold
+	}
+	return defaultLatencyThreshold
+}
+
+func latencySpike(cfg AuditConfig, e LatencyEvent, history []LatencySample, threshold time.Duration) Finding {
+	var over int
+	var first, last time.Time
+	for _, h := range history {
+		if h.Latency < threshold {
+			continue
+		}
+		over++
+		if first.IsZero() || h.Time.Before(first) {
+			first = h.Time
+		}
+		if h.Time.After(last) {
+			last = h.Time
+		}
+	}
+
+	severity := SeverityMedium
+	if e.Max >= latencyHighThreshold {
+		severity = SeverityHigh
+	}
+
+	meta := map[string]any{
+		"event":                  e.Name,
+		"latest_ms":              e.Latest.Milliseconds(),
+		"max_ms":                 e.Max.Milliseconds(),
+		"last_spike":             e.Time.UTC().Format(time.RFC3339),
+		"threshold_ms":           threshold.Milliseconds(),
+		"history_samples":        len(history),
+		"samples_over_threshold": over,
+	}
+	if over > 0 {
+		meta["first_over_threshold"] = first.UTC().Format(time.RFC3339)
+		meta["last_over_threshold"] = last.UTC().Format(time.RFC3339)
+	}
+
+	return Finding{
+		ID:           FindingLatencySpike,
+		Severity:     severity,
+		ResourceType: "Latency",
+		ResourceID:   cfg.Addr + "/" + e.Name,
+		Message: fmt.Sprintf("%s latency reached %dms (latest %dms, threshold %dms; %d of %d recent samples over)",
+			e.Name, e.Max.Milliseconds(), e.Latest.Milliseconds(), threshold.Milliseconds(), over, len(history)),
+		Metadata: meta,
+	}
+}
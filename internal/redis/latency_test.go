@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLatencyScanner_Name(t *testing.T) {
+	s := &LatencyScanner{}
+	if s.Name() != "latency" {
+		t.Errorf("expected name 'latency', got %q", s.Name())
+	}
+}
+
+func TestLatencyScanner_MonitorDisabled(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["latency-monitor-threshold"] = map[string]string{"latency-monitor-threshold": "0"}
+
+	s := &LatencyScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingLatencyMonitorOff {
+		t.Fatalf("expected 1 LATENCY_MONITOR_DISABLED finding, got %+v", findings)
+	}
+	if findings[0].Metadata["suggested_config"] != "latency-monitor-threshold 100" {
+		t.Errorf("unexpected suggestion: %v", findings[0].Metadata["suggested_config"])
+	}
+}
+
+func TestLatencyScanner_Spikes(t *testing.T) {
+	now := time.Now()
+	mock := newMockClient()
+	mock.configValues["latency-monitor-threshold"] = map[string]string{"latency-monitor-threshold": "50"}
+	mock.latencyLatest = []LatencyEvent{
+		{Name: "command", Time: now, Latest: 60 * time.Millisecond, Max: 80 * time.Millisecond},
+		{Name: "fork", Time: now, Latest: 300 * time.Millisecond, Max: 1500 * time.Millisecond},
+		{Name: "expire-cycle", Time: now, Latest: 120 * time.Millisecond, Max: 250 * time.Millisecond},
+	}
+	mock.latencyHistory = map[string][]LatencySample{
+		"fork": {
+			{Time: now.Add(-2 * time.Hour), Latency: 1500 * time.Millisecond},
+			{Time: now.Add(-time.Hour), Latency: 200 * time.Millisecond},
+			{Time: now, Latency: 300 * time.Millisecond},
+		},
+		"expire-cycle": {
+			{Time: now, Latency: 250 * time.Millisecond},
+		},
+	}
+	mock.latencyDoctor = "Dave, I have observed latency spikes in this Redis instance."
+
+	cfg := AuditConfig{
+		Addr:                   "localhost:6379",
+		LatencyEventThresholds: map[string]time.Duration{"fork": 250 * time.Millisecond},
+	}
+	s := &LatencyScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 spikes (command under threshold), got %+v", findings)
+	}
+
+	fork := findings[0]
+	if fork.ResourceID != "localhost:6379/fork" || fork.Severity != SeverityHigh {
+		t.Errorf("expected high fork spike first, got %s %s", fork.ResourceID, fork.Severity)
+	}
+	if fork.Metadata["samples_over_threshold"] != 2 || fork.Metadata["threshold_ms"] != int64(250) {
+		t.Errorf("unexpected fork metadata: %v", fork.Metadata)
+	}
+	if fork.Metadata["latency_doctor"] != mock.latencyDoctor {
+		t.Error("expected LATENCY DOCTOR text as evidence")
+	}
+
+	expire := findings[1]
+	if expire.Metadata["event"] != "expire-cycle" || expire.Severity != SeverityMedium {
+		t.Errorf("expected medium expire-cycle spike, got %v %s", expire.Metadata["event"], expire.Severity)
+	}
+}
+
+func TestLatencyScanner_NoDoctor(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["latency-monitor-threshold"] = map[string]string{"latency-monitor-threshold": "100"}
+	mock.latencyLatest = []LatencyEvent{
+		{Name: "eviction-cycle", Time: time.Now(), Latest: 400 * time.Millisecond, Max: 400 * time.Millisecond},
+	}
+
+	s := &LatencyScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{LatencyThreshold: 300 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %+v", findings)
+	}
+	if _, ok := findings[0].Metadata["latency_doctor"]; ok {
+		t.Error("expected no doctor text when LATENCY DOCTOR is unavailable")
+	}
+}
+
+func TestLatencyScanner_Error(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["latency-monitor-threshold"] = map[string]string{"latency-monitor-threshold": "100"}
+	mock.latencyErr = errors.New("NOPERM")
+
+	s := &LatencyScanner{}
+	if _, err := s.Audit(context.Background(), mock, AuditConfig{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,291 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mallocTopBins is the number of size classes listed in a diagnosis.
+	mallocTopBins = 5
+	// defaultPageSize is used when the stats omit the page size.
+	defaultPageSize = 4096
+)
+
+// binStats is one jemalloc small size class from the bins table.
+type binStats struct {
+	Size        int64   `json:"size"`
+	Regs        int64   `json:"regs_per_slab"`
+	CurRegs     int64   `json:"cur_regs"`
+	CurSlabs    int64   `json:"cur_slabs"`
+	Util        float64 `json:"util"`
+	WastedBytes int64   `json:"wasted_bytes"`
+}
+
+// mallocStats is the parsed subset of MEMORY MALLOC-STATS.
+type mallocStats struct {
+	Version     string
+	PageSize    int64
+	Allocated   int64
+	Active      int64
+	Resident    int64
+	DirtyPages  int64
+	MuzzyPages  int64
+	Bins        []binStats
+	HasBinTable bool
+}
+
+var mallocSummaryRe = regexp.MustCompile(`(\w+): (\d+)`)
+
+// ParseMallocStats parses jemalloc's text statistics as returned by MEMORY
+// MALLOC-STATS. It reads the merged-arena section when present, otherwise the
+// first arena. Column positions in the bins table are taken from its header,
+// since they vary between jemalloc versions.
+func ParseMallocStats(raw string) mallocStats {
+	st := mallocStats{PageSize: defaultPageSize}
+	lines := strings.Split(raw, "\n")
+
+	start := 0
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "Merged arenas stats:") {
+			start = i
+			break
+		}
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "Version:"):
+			st.Version = strings.Trim(strings.TrimSpace(strings.TrimPrefix(trimmed, "Version:")), `"`)
+		case strings.HasPrefix(trimmed, "Page size:"):
+			if n, err := strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(trimmed, "Page size:")), 10, 64); err == nil && n > 0 {
+				st.PageSize = n
+			}
+		case strings.HasPrefix(trimmed, "Allocated:") && st.Allocated == 0:
+			for _, m := range mallocSummaryRe.FindAllStringSubmatch(trimmed, -1) {
+				n, _ := strconv.ParseInt(m[2], 10, 64)
+				switch m[1] {
+				case "Allocated":
+					st.Allocated = n
+				case "active":
+					st.Active = n
+				case "resident":
+					st.Resident = n
+				}
+			}
+		}
+		if i < start {
+			continue
+		}
+		fields := strings.Fields(trimmed)
+		switch {
+		case len(fields) >= 3 && fields[0] == "dirty:" && st.DirtyPages == 0:
+			st.DirtyPages, _ = strconv.ParseInt(fields[2], 10, 64)
+		case len(fields) >= 3 && fields[0] == "muzzy:" && st.MuzzyPages == 0:
+			st.MuzzyPages, _ = strconv.ParseInt(fields[2], 10, 64)
+		case len(fields) > 0 && fields[0] == "bins:" && !st.HasBinTable:
+			st.Bins = parseBinTable(fields[1:], lines[i+1:])
+			st.HasBinTable = true
+		}
+	}
+	return st
+}
+
+// parseBinTable reads bin rows until the table ends. header holds the
+// column names after "bins:".
+func parseBinTable(header []string, rows []string) []binStats {
+	col := make(map[string]int)
+	for i, name := range header {
+		if _, ok := col[name]; !ok {
+			col[name] = i
+		}
+	}
+	for _, name := range []string{"size", "regs", "curregs", "curslabs"} {
+		if _, ok := col[name]; !ok {
+			return nil
+		}
+	}
+
+	var bins []binStats
+	for _, row := range rows {
+		fields := strings.Fields(row)
+		if len(fields) == 0 {
+			break
+		}
+		if fields[0] == "---" {
+			continue
+		}
+		if len(fields) != len(header) {
+			break
+		}
+		num := func(name string) int64 {
+			n, _ := strconv.ParseInt(fields[col[name]], 10, 64)
+			return n
+		}
+		b := binStats{
+			Size:     num("size"),
+			Regs:     num("regs"),
+			CurRegs:  num("curregs"),
+			CurSlabs: num("curslabs"),
+		}
+		capacity := b.CurSlabs * b.Regs
+		if capacity > 0 {
+			b.Util = float64(b.CurRegs) / float64(capacity)
+		}
+		if i, ok := col["util"]; ok {
+			if u, err := strconv.ParseFloat(fields[i], 64); err == nil {
+				b.Util = u
+			}
+		}
+		b.WastedBytes = (capacity - b.CurRegs) * b.Size
+		bins = append(bins, b)
+	}
+	return bins
+}
+
+// mallocDiagnosis explains where jemalloc fragmentation comes from.
+type mallocDiagnosis struct {
+	Version         string     `json:"version,omitempty"`
+	Allocated       int64      `json:"allocated"`
+	Active          int64      `json:"active"`
+	Resident        int64      `json:"resident"`
+	BinUtilization  float64    `json:"bin_utilization"`
+	BinWastedBytes  int64      `json:"bin_wasted_bytes"`
+	DirtyBytes      int64      `json:"dirty_bytes"`
+	MuzzyBytes      int64      `json:"muzzy_bytes"`
+	TopBins         []binStats `json:"top_bins,omitempty"`
+	DefragCouldHelp bool       `json:"defrag_could_help"`
+	Advice          string     `json:"advice"`
+}
+
+func diagnoseMallocStats(st mallocStats) mallocDiagnosis {
+	d := mallocDiagnosis{
+		Version:    st.Version,
+		Allocated:  st.Allocated,
+		Active:     st.Active,
+		Resident:   st.Resident,
+		DirtyBytes: st.DirtyPages * st.PageSize,
+		MuzzyBytes: st.MuzzyPages * st.PageSize,
+	}
+
+	var used, capacity int64
+	for _, b := range st.Bins {
+		used += b.CurRegs * b.Size
+		capacity += b.CurSlabs * b.Regs * b.Size
+		d.BinWastedBytes += b.WastedBytes
+	}
+	if capacity > 0 {
+		d.BinUtilization = float64(used) / float64(capacity)
+	}
+
+	bins := make([]binStats, 0, len(st.Bins))
+	for _, b := range st.Bins {
+		if b.WastedBytes > 0 {
+			bins = append(bins, b)
+		}
+	}
+	sort.SliceStable(bins, func(i, j int) bool { return bins[i].WastedBytes > bins[j].WastedBytes })
+	if len(bins) > mallocTopBins {
+		bins = bins[:mallocTopBins]
+	}
+	d.TopBins = bins
+
+	unreturned := d.DirtyBytes + d.MuzzyBytes
+	switch {
+	case d.BinWastedBytes >= fragMinWastedBytes && d.BinWastedBytes >= unreturned:
+		d.DefragCouldHelp = true
+		d.Advice = "partly used slabs in small size classes hold most of the waste; active defrag relocates these allocations and can reclaim it"
+	case unreturned >= fragMinWastedBytes:
+		d.Advice = "most of the waste is dirty or muzzy pages jemalloc has not returned; MEMORY PURGE releases them, active defrag will not"
+	default:
+		d.Advice = "jemalloc bins and page caches hold little waste; fragmentation is outside the small size classes"
+	}
+	return d
+}
+
+// totalWasted is the memory the diagnosis accounts for.
+func (d mallocDiagnosis) totalWasted() int64 {
+	return d.BinWastedBytes + d.DirtyBytes + d.MuzzyBytes
+}
+
+// MallocStatsScanner parses MEMORY MALLOC-STATS to explain jemalloc
+// fragmentation by size class. It is optional because the output is large.
+type MallocStatsScanner struct{}
+
+func (s *MallocStatsScanner) Name() string { return "malloc_stats" }
+
+// Applicable requires MEMORY MALLOC-STATS from jemalloc.
+func (s *MallocStatsScanner) Applicable(p *Profile) (string, bool) { return p.require(CapMallocStats) }
+
+func (s *MallocStatsScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.MallocStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("memory malloc-stats: %w", err)
+	}
+	st := ParseMallocStats(raw)
+	if !st.HasBinTable {
+		// Not jemalloc ("Stats not supported for the current allocator").
+		return nil, nil
+	}
+
+	d := diagnoseMallocStats(st)
+	cfg.inventory.set("malloc_stats", d)
+
+	if d.totalWasted() < fragMinWastedBytes {
+		return nil, nil
+	}
+
+	msg := fmt.Sprintf("jemalloc holds %s unused: %s in partly used slabs (bin utilization %.0f%%), %s dirty and %s muzzy pages",
+		FormatBytes(d.totalWasted()), FormatBytes(d.BinWastedBytes), d.BinUtilization*100,
+		FormatBytes(d.DirtyBytes), FormatBytes(d.MuzzyBytes))
+	if len(d.TopBins) > 0 {
+		msg += fmt.Sprintf("; worst size class %d bytes", d.TopBins[0].Size)
+	}
+
+	return []Finding{{
+		ID:           FindingFragmentationDiagnosis,
+		Severity:     SeverityLow,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message:      msg,
+		Metadata: map[string]any{
+			"diagnosis":      d,
+			"recommendation": d.Advice,
+		},
+	}}, nil
+}
+
+// attachFragmentationDiagnosis folds the malloc_stats diagnosis recorded in
+// the inventory into HIGH_FRAGMENTATION findings, so it is reported next to
+// the fragmentation it explains. The standalone FRAGMENTATION_DIAGNOSIS
+// finding is dropped once merged; without a fragmentation finding it stays.
+func attachFragmentationDiagnosis(findings []Finding, inv *inventory) []Finding {
+	d, ok := inv.get("malloc_stats").(mallocDiagnosis)
+	if !ok {
+		return findings
+	}
+
+	merged := false
+	for i, f := range findings {
+		if f.ID == FindingHighFragmentation {
+			findings[i].Metadata["diagnosis"] = d
+			merged = true
+		}
+	}
+	if !merged {
+		return findings
+	}
+
+	out := findings[:0]
+	for _, f := range findings {
+		if f.ID != FindingFragmentationDiagnosis {
+			out = append(out, f)
+		}
+	}
+	return out
+}
@@ -0,0 +1,145 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+const jemallocStatsFixture = `___ Begin jemalloc statistics ___
+Version: "5.3.0-0-g54eaed1d8b56b1aa528be3bdd1877e59c56fa90c"
+Build-time option settings
+  config.debug: false
+Run-time option settings
+  opt.abort: false
+Arenas: 4
+Quantum size: 8
+Page size: 4096
+Allocated: 41600000, active: 180000000, metadata: 2715072 (n_thp 0), resident: 200000000, mapped: 220000000, retained: 1343488
+Merged arenas stats:
+assigned threads: 1
+uptime: 42005869
+dss allocation precedence: "N/A"
+decaying:  time       npages       sweeps     madvises       purged
+   dirty:   N/A         2048            0            0            0
+   muzzy:   N/A          512            0            0            0
+bins:           size ind    allocated      nmalloc (#/sec)      ndalloc (#/sec)    nrequests   (#/sec)  nshards      curregs     curslabs  nonfull_slabs regs pgs   util       nfills (#/sec)
+                   8    0     40000000      6000000      10      1000000       2      9000000      20        1      5000000        40000          30000  512   1  0.244          100       0
+                  16    1      1600000       100000       1            0       0       100000       1        1       100000          400              0  256   1  0.976           10       0
+                     ---
+large:          size ind    allocated      nmalloc (#/sec)      ndalloc (#/sec)    nrequests   (#/sec)  curlextents
+arenas[0]:
+bins:           size ind    allocated      nmalloc (#/sec)      ndalloc (#/sec)    nrequests   (#/sec)  nshards      curregs     curslabs  nonfull_slabs regs pgs   util       nfills (#/sec)
+                   8    0            8            1       0            0       0            1       0        1            1            1              1  512   1  0.001            1       0
+--- End jemalloc statistics ---
+`
+
+func TestParseMallocStats(t *testing.T) {
+	st := ParseMallocStats(jemallocStatsFixture)
+	if !st.HasBinTable {
+		t.Fatal("expected a bins table")
+	}
+	if !strings.HasPrefix(st.Version, "5.3.0") || st.PageSize != 4096 {
+		t.Errorf("unexpected version %q or page size %d", st.Version, st.PageSize)
+	}
+	if st.Allocated != 41600000 || st.Active != 180000000 || st.Resident != 200000000 {
+		t.Errorf("unexpected summary: %+v", st)
+	}
+	if st.DirtyPages != 2048 || st.MuzzyPages != 512 {
+		t.Errorf("expected 2048 dirty and 512 muzzy pages, got %d and %d", st.DirtyPages, st.MuzzyPages)
+	}
+	if len(st.Bins) != 2 {
+		t.Fatalf("expected 2 merged bins, got %d", len(st.Bins))
+	}
+	b := st.Bins[0]
+	if b.Size != 8 || b.Regs != 512 || b.CurSlabs != 40000 || b.CurRegs != 5000000 || b.Util != 0.244 {
+		t.Errorf("unexpected bin: %+v", b)
+	}
+	if b.WastedBytes != (40000*512-5000000)*8 {
+		t.Errorf("unexpected wasted bytes %d", b.WastedBytes)
+	}
+}
+
+func TestParseMallocStats_NotJemalloc(t *testing.T) {
+	if ParseMallocStats("Stats not supported for the current allocator").HasBinTable {
+		t.Error("expected no bins table")
+	}
+}
+
+func TestDiagnoseMallocStats_DirtyPages(t *testing.T) {
+	d := diagnoseMallocStats(mallocStats{PageSize: 4096, DirtyPages: 50000, MuzzyPages: 10000})
+	if d.DefragCouldHelp {
+		t.Error("expected defrag not to help with dirty pages")
+	}
+	if !strings.Contains(d.Advice, "MEMORY PURGE") {
+		t.Errorf("expected MEMORY PURGE advice, got %q", d.Advice)
+	}
+}
+
+func TestMallocStatsScanner_Name(t *testing.T) {
+	s := &MallocStatsScanner{}
+	if s.Name() != "malloc_stats" {
+		t.Errorf("expected name 'malloc_stats', got %q", s.Name())
+	}
+}
+
+func TestMallocStatsScanner_Diagnosis(t *testing.T) {
+	mock := newMockClient()
+	mock.mallocStats = jemallocStatsFixture
+	cfg := AuditConfig{Addr: "localhost:6379", inventory: &inventory{data: make(map[string]any)}}
+
+	s := &MallocStatsScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingFragmentationDiagnosis {
+		t.Fatalf("expected 1 FRAGMENTATION_DIAGNOSIS finding, got %+v", findings)
+	}
+	d, ok := findings[0].Metadata["diagnosis"].(mallocDiagnosis)
+	if !ok {
+		t.Fatal("expected diagnosis metadata")
+	}
+	if !d.DefragCouldHelp || len(d.TopBins) != 2 || d.TopBins[0].Size != 8 {
+		t.Errorf("unexpected diagnosis: %+v", d)
+	}
+	if _, ok := cfg.inventory.data["malloc_stats"]; !ok {
+		t.Error("expected malloc_stats inventory")
+	}
+}
+
+func TestMallocStatsScanner_NotJemalloc(t *testing.T) {
+	mock := newMockClient()
+	mock.mallocStats = "Stats not supported for the current allocator"
+
+	s := &MallocStatsScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestMultiAuditorAttachesFragmentationDiagnosis(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["memory"] = "mem_fragmentation_ratio:2.5\nused_memory:1073741824\nused_memory_rss:2684354560\n"
+	mock.mallocStats = jemallocStatsFixture
+
+	multi := NewMultiAuditor([]Auditor{&MemoryScanner{}, &MallocStatsScanner{}}, 2)
+	result, err := multi.AuditAll(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Findings) != 1 {
+		t.Fatalf("expected the diagnosis merged into 1 finding, got %+v", result.Findings)
+	}
+	f := result.Findings[0]
+	if f.ID != FindingHighFragmentation {
+		t.Fatalf("expected HIGH_FRAGMENTATION, got %s", f.ID)
+	}
+	if _, ok := f.Metadata["diagnosis"].(mallocDiagnosis); !ok {
+		t.Error("expected diagnosis on the fragmentation finding")
+	}
+}
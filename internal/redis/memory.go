@@ -3,10 +3,35 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
 )
 
-// MemoryScanner audits Redis memory for high fragmentation.
+const (
+	// fragRatioThreshold is the mem_fragmentation_ratio (RSS / used memory)
+	// reported as fragmentation.
+	fragRatioThreshold = 1.5
+	// fragComponentRatio is the threshold for the allocator_frag,
+	// allocator_rss, and rss_overhead ratios.
+	fragComponentRatio = 1.3
+	// fragMinWastedBytes ignores ratios that waste little memory in absolute
+	// terms, as on small datasets where any ratio is noise.
+	fragMinWastedBytes = 64 * 1024 * 1024
+	// fragHighWastedBytes raises fragmentation to high severity.
+	fragHighWastedBytes = 1024 * 1024 * 1024
+	// swapMinUsedMemory skips the swap check on small datasets, where RSS
+	// can trail used memory for reasons other than swapping.
+	swapMinUsedMemory = 64 * 1024 * 1024
+)
+
+// fragComponent is one source of wasted memory reported by INFO memory.
+type fragComponent struct {
+	Cause string
+	Ratio float64
+	Bytes int64
+}
+
+// MemoryScanner audits Redis memory for fragmentation and swapping.
 type MemoryScanner struct{}
 
 func (s *MemoryScanner) Name() string { return "memory" }
@@ -31,25 +56,129 @@ func (s *MemoryScanner) Audit(ctx context.Context, client RedisClient, cfg Audit
 		return nil, fmt.Errorf("parse fragmentation ratio: %w", err)
 	}
 
-	if fragRatio > 1.5 {
-		usedMemory, _ := strconv.ParseInt(info["used_memory"], 10, 64)
-		usedMemoryRSS, _ := strconv.ParseInt(info["used_memory_rss"], 10, 64)
+	usedMemory, _ := strconv.ParseInt(info["used_memory"], 10, 64)
+	usedMemoryRSS, _ := strconv.ParseInt(info["used_memory_rss"], 10, 64)
 
+	if fragRatio > 0 && fragRatio < 1 && usedMemory >= swapMinUsedMemory {
 		findings = append(findings, Finding{
-			ID:           FindingHighFragmentation,
+			ID:           FindingMemorySwapping,
 			Severity:     SeverityHigh,
 			ResourceType: "Redis",
 			ResourceID:   cfg.Addr,
-			Message:      fmt.Sprintf("memory fragmentation ratio %.2f exceeds threshold 1.5", fragRatio),
+			Message: fmt.Sprintf("resident memory %s is below used memory %s (ratio %.2f); part of the dataset is swapped out",
+				FormatBytes(usedMemoryRSS), FormatBytes(usedMemory), fragRatio),
 			Metadata: map[string]any{
-				"fragmentation_ratio":   fragRatio,
-				"used_memory":           usedMemory,
-				"used_memory_human":     FormatBytes(usedMemory),
-				"used_memory_rss":       usedMemoryRSS,
-				"used_memory_rss_human": FormatBytes(usedMemoryRSS),
+				"fragmentation_ratio": fragRatio,
+				"used_memory":         usedMemory,
+				"used_memory_rss":     usedMemoryRSS,
+				"swapped_bytes":       usedMemory - usedMemoryRSS,
+				"recommendation":      "set maxmemory below available RAM, disable swap for the host, or move to a larger instance",
 			},
 		})
+		return findings, nil
+	}
+
+	component, ok := fragmentationCause(info, fragRatio, usedMemory, usedMemoryRSS)
+	if !ok {
+		return findings, nil
+	}
+
+	var activeDefrag bool
+	if cfg.supports(CapConfig) {
+		defragConfig, err := client.ConfigGet(ctx, "activedefrag")
+		if err != nil {
+			return nil, fmt.Errorf("config get activedefrag: %w", err)
+		}
+		activeDefrag = defragConfig["activedefrag"] == "yes"
+	} else {
+		cfg.skip(s.Name(), "activedefrag", CapConfig)
+	}
+	statsRaw, err := client.Info(ctx, "stats")
+	if err != nil {
+		return nil, fmt.Errorf("info stats: %w", err)
+	}
+	stats := ParseInfo(statsRaw)
+
+	defragRunning := infoInt(info, "active_defrag_running") > 0
+	defragHits := infoInt(stats, "active_defrag_hits")
+
+	severity := SeverityMedium
+	if component.Bytes >= fragHighWastedBytes || fragRatio >= 2 {
+		severity = SeverityHigh
 	}
 
+	findings = append(findings, Finding{
+		ID:           FindingHighFragmentation,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("memory fragmentation ratio %.2f wastes %s (largest source: %s, ratio %.2f)",
+			fragRatio, FormatBytes(component.Bytes), component.Cause, component.Ratio),
+		Metadata: map[string]any{
+			"fragmentation_ratio":   fragRatio,
+			"used_memory":           usedMemory,
+			"used_memory_human":     FormatBytes(usedMemory),
+			"used_memory_rss":       usedMemoryRSS,
+			"used_memory_rss_human": FormatBytes(usedMemoryRSS),
+			"cause":                 component.Cause,
+			"cause_ratio":           component.Ratio,
+			"wasted_bytes":          component.Bytes,
+			"allocator":             info["mem_allocator"],
+			"activedefrag":          activeDefrag,
+			"active_defrag_running": defragRunning,
+			"active_defrag_hits":    defragHits,
+			"active_defrag_misses":  infoInt(stats, "active_defrag_misses"),
+			"recommendation":        fragmentationAdvice(component.Cause, activeDefrag, defragRunning, defragHits),
+		},
+	})
+
 	return findings, nil
 }
+
+// fragmentationCause returns the largest source of wasted memory that
+// exceeds its ratio threshold and the absolute minimum. Servers without
+// allocator statistics fall back to the overall ratio.
+func fragmentationCause(info map[string]string, fragRatio float64, usedMemory, usedMemoryRSS int64) (fragComponent, bool) {
+	var candidates []fragComponent
+	for _, c := range []fragComponent{
+		{"allocator_frag", infoFloat(info, "allocator_frag_ratio"), infoInt(info, "allocator_frag_bytes")},
+		{"allocator_rss", infoFloat(info, "allocator_rss_ratio"), infoInt(info, "allocator_rss_bytes")},
+		{"rss_overhead", infoFloat(info, "rss_overhead_ratio"), infoInt(info, "rss_overhead_bytes")},
+	} {
+		if c.Ratio >= fragComponentRatio && c.Bytes >= fragMinWastedBytes {
+			candidates = append(candidates, c)
+		}
+	}
+	if len(candidates) > 0 {
+		sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Bytes > candidates[j].Bytes })
+		return candidates[0], true
+	}
+
+	wasted := infoInt(info, "mem_fragmentation_bytes")
+	if wasted == 0 {
+		wasted = usedMemoryRSS - usedMemory
+	}
+	if fragRatio > fragRatioThreshold && wasted >= fragMinWastedBytes {
+		return fragComponent{Cause: "total", Ratio: fragRatio, Bytes: wasted}, true
+	}
+	return fragComponent{}, false
+}
+
+// fragmentationAdvice recommends a fix for the given source of waste.
+func fragmentationAdvice(cause string, activeDefrag, running bool, hits int64) string {
+	switch cause {
+	case "allocator_frag", "total":
+		switch {
+		case !activeDefrag:
+			return "enable activedefrag yes (jemalloc builds) to compact fragmented allocations online"
+		case !running && hits == 0:
+			return "activedefrag is enabled but has not reclaimed anything; lower active-defrag-ignore-bytes and active-defrag-threshold-lower, or raise active-defrag-cycle-max"
+		default:
+			return "activedefrag is compacting memory; raise active-defrag-cycle-max if it cannot keep up"
+		}
+	case "allocator_rss":
+		return "run MEMORY PURGE to return freed allocator pages to the OS"
+	default:
+		return "RSS outside the allocator is high; check for a running save or rewrite child, large Lua memory, or a recent peak that was not released"
+	}
+}
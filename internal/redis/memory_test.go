@@ -14,7 +14,7 @@ func TestMemoryScanner_Name(t *testing.T) {
 
 func TestMemoryScanner_HighFragmentation(t *testing.T) {
 	mock := newMockClient()
-	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:2.50\nused_memory:1048576\nused_memory_rss:2621440\n"
+	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:2.50\nused_memory:1073741824\nused_memory_rss:2684354560\n"
 
 	s := &MemoryScanner{}
 	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
@@ -30,6 +30,92 @@ func TestMemoryScanner_HighFragmentation(t *testing.T) {
 	if findings[0].Severity != SeverityHigh {
 		t.Errorf("expected severity high, got %q", findings[0].Severity)
 	}
+	if findings[0].Metadata["cause"] != "total" {
+		t.Errorf("expected total as cause without allocator stats, got %v", findings[0].Metadata["cause"])
+	}
+	if findings[0].Metadata["recommendation"] != fragmentationAdvice("total", false, false, 0) {
+		t.Errorf("expected activedefrag recommendation, got %v", findings[0].Metadata["recommendation"])
+	}
+}
+
+func TestMemoryScanner_SmallDatasetIgnored(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:2.50\nused_memory:1048576\nused_memory_rss:2621440\n"
+
+	s := &MemoryScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings on a 1MB dataset, got %d", len(findings))
+	}
+}
+
+func TestMemoryScanner_Swapping(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:0.60\nused_memory:1073741824\nused_memory_rss:644245094\n"
+
+	s := &MemoryScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingMemorySwapping {
+		t.Fatalf("expected 1 MEMORY_SWAPPING finding, got %+v", findings)
+	}
+	if findings[0].Severity != SeverityHigh {
+		t.Errorf("expected severity high, got %q", findings[0].Severity)
+	}
+}
+
+func TestMemoryScanner_AllocatorCauses(t *testing.T) {
+	tests := []struct {
+		name      string
+		memory    string
+		defrag    string
+		stats     string
+		wantCause string
+		wantAdv   string
+	}{
+		{
+			name:      "allocator fragmentation, defrag ineffective",
+			memory:    "mem_fragmentation_ratio:1.40\nused_memory:2147483648\nused_memory_rss:3006477107\nallocator_frag_ratio:1.35\nallocator_frag_bytes:751619276\nallocator_rss_ratio:1.02\nallocator_rss_bytes:20000000\nrss_overhead_ratio:1.01\nrss_overhead_bytes:10000000\nactive_defrag_running:0\n",
+			defrag:    "yes",
+			stats:     "active_defrag_hits:0\nactive_defrag_misses:0\n",
+			wantCause: "allocator_frag",
+			wantAdv:   fragmentationAdvice("allocator_frag", true, false, 0),
+		},
+		{
+			name:      "allocator retains freed pages",
+			memory:    "mem_fragmentation_ratio:1.60\nused_memory:1073741824\nused_memory_rss:1717986918\nallocator_frag_ratio:1.05\nallocator_frag_bytes:50000000\nallocator_rss_ratio:1.50\nallocator_rss_bytes:600000000\n",
+			wantCause: "allocator_rss",
+			wantAdv:   "run MEMORY PURGE to return freed allocator pages to the OS",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.infoResponses["memory"] = tt.memory
+			mock.infoResponses["stats"] = tt.stats
+			mock.configValues["activedefrag"] = map[string]string{"activedefrag": tt.defrag}
+
+			s := &MemoryScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(findings) != 1 || findings[0].ID != FindingHighFragmentation {
+				t.Fatalf("expected 1 HIGH_FRAGMENTATION finding, got %+v", findings)
+			}
+			if findings[0].Metadata["cause"] != tt.wantCause {
+				t.Errorf("expected cause %q, got %v", tt.wantCause, findings[0].Metadata["cause"])
+			}
+			if findings[0].Metadata["recommendation"] != tt.wantAdv {
+				t.Errorf("expected %q, got %v", tt.wantAdv, findings[0].Metadata["recommendation"])
+			}
+		})
+	}
 }
 
 func TestMemoryScanner_NormalFragmentation(t *testing.T) {
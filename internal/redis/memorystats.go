@@ -0,0 +1,167 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// overheadMinAllocated skips the overhead check on small instances, where
+	// startup allocations and empty hashtables dominate any dataset.
+	overheadMinAllocated = 64 * 1024 * 1024
+	// overheadDatasetPercent and overheadHighDatasetPercent are the dataset
+	// shares of used memory below which overhead is reported as medium and high.
+	overheadDatasetPercent     = 50
+	overheadHighDatasetPercent = 25
+)
+
+// overheadAdvice explains how to shrink each MEMORY STATS overhead category.
+var overheadAdvice = map[string]string{
+	"replication_backlog": "lower repl-backlog-size if replicas reconnect within a shorter window",
+	"clients_normal":      "find clients with large query or output buffers in CLIENT LIST (qbuf, omem)",
+	"clients_replicas":    "replica output buffers are large; check replica lag and client-output-buffer-limit replica",
+	"aof_buffer":          "the AOF buffer is large; check disk write latency and appendfsync",
+	"lua_caches":          "many scripts are cached; load scripts once and call them with EVALSHA, or SCRIPT FLUSH",
+	"functions_caches":    "many functions are loaded; remove unused libraries with FUNCTION DELETE",
+	"cluster_links":       "cluster bus link buffers are large; check for slow or partitioned nodes",
+	"db_hashtables":       "many small keys; group related fields into hashes to cut per-key overhead",
+}
+
+// memoryBreakdown is the MEMORY STATS breakdown included in findings and in
+// the report inventory.
+type memoryBreakdown struct {
+	PeakAllocated      int64                 `json:"peak_allocated"`
+	TotalAllocated     int64                 `json:"total_allocated"`
+	StartupAllocated   int64                 `json:"startup_allocated"`
+	ReplicationBacklog int64                 `json:"replication_backlog"`
+	ClientsReplicas    int64                 `json:"clients_replicas"`
+	ClientsNormal      int64                 `json:"clients_normal"`
+	ClusterLinks       int64                 `json:"cluster_links"`
+	AOFBuffer          int64                 `json:"aof_buffer"`
+	LuaCaches          int64                 `json:"lua_caches"`
+	FunctionsCaches    int64                 `json:"functions_caches"`
+	DBHashtables       map[string]dbOverhead `json:"db_hashtables,omitempty"`
+	OverheadTotal      int64                 `json:"overhead_total"`
+	Dataset            int64                 `json:"dataset"`
+	DatasetPercent     float64               `json:"dataset_percent"`
+	KeysCount          int64                 `json:"keys_count"`
+	BytesPerKey        int64                 `json:"bytes_per_key"`
+}
+
+// dbOverhead is the main and expires hashtable overhead of one database.
+type dbOverhead struct {
+	Main    int64 `json:"main"`
+	Expires int64 `json:"expires"`
+}
+
+func parseMemoryStats(stats map[string]any) memoryBreakdown {
+	b := memoryBreakdown{
+		PeakAllocated:      replyInt(stats["peak.allocated"]),
+		TotalAllocated:     replyInt(stats["total.allocated"]),
+		StartupAllocated:   replyInt(stats["startup.allocated"]),
+		ReplicationBacklog: replyInt(stats["replication.backlog"]),
+		ClientsReplicas:    replyInt(stats["clients.slaves"]),
+		ClientsNormal:      replyInt(stats["clients.normal"]),
+		ClusterLinks:       replyInt(stats["cluster.links"]),
+		AOFBuffer:          replyInt(stats["aof.buffer"]),
+		LuaCaches:          replyInt(stats["lua.caches"]),
+		FunctionsCaches:    replyInt(stats["functions.caches"]),
+		OverheadTotal:      replyInt(stats["overhead.total"]),
+		Dataset:            replyInt(stats["dataset.bytes"]),
+		DatasetPercent:     replyFloat(stats["dataset.percentage"]),
+		KeysCount:          replyInt(stats["keys.count"]),
+		BytesPerKey:        replyInt(stats["keys.bytes-per-key"]),
+	}
+	for k, v := range stats {
+		if !strings.HasPrefix(k, "db.") {
+			continue
+		}
+		db, ok := replyMap(v)
+		if !ok {
+			continue
+		}
+		if b.DBHashtables == nil {
+			b.DBHashtables = make(map[string]dbOverhead)
+		}
+		b.DBHashtables[k] = dbOverhead{
+			Main:    replyInt(db["overhead.hashtable.main"]),
+			Expires: replyInt(db["overhead.hashtable.expires"]),
+		}
+	}
+	return b
+}
+
+// categories returns the non-dataset overhead categories by size, largest
+// first.
+func (b memoryBreakdown) categories() []overheadCategory {
+	var hashtables int64
+	for _, db := range b.DBHashtables {
+		hashtables += db.Main + db.Expires
+	}
+	cats := []overheadCategory{
+		{"replication_backlog", b.ReplicationBacklog},
+		{"clients_normal", b.ClientsNormal},
+		{"clients_replicas", b.ClientsReplicas},
+		{"aof_buffer", b.AOFBuffer},
+		{"lua_caches", b.LuaCaches},
+		{"functions_caches", b.FunctionsCaches},
+		{"cluster_links", b.ClusterLinks},
+		{"db_hashtables", hashtables},
+	}
+	sort.SliceStable(cats, func(i, j int) bool { return cats[i].Bytes > cats[j].Bytes })
+	return cats
+}
+
+type overheadCategory struct {
+	Name  string
+	Bytes int64
+}
+
+// MemoryOverheadScanner audits MEMORY STATS for memory that is not the
+// dataset: replication backlog, client buffers, AOF buffer, script caches,
+// and per-database hashtables.
+type MemoryOverheadScanner struct{}
+
+func (s *MemoryOverheadScanner) Name() string { return "memory_overhead" }
+
+// Applicable requires MEMORY STATS (Redis 4.0+).
+func (s *MemoryOverheadScanner) Applicable(p *Profile) (string, bool) {
+	return p.require(CapMemoryStats)
+}
+
+func (s *MemoryOverheadScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	stats, err := client.MemoryStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("memory stats: %w", err)
+	}
+	b := parseMemoryStats(stats)
+	cfg.inventory.set("memory_stats", b)
+
+	if b.TotalAllocated < overheadMinAllocated || b.DatasetPercent >= overheadDatasetPercent {
+		return nil, nil
+	}
+
+	severity := SeverityMedium
+	if b.DatasetPercent < overheadHighDatasetPercent {
+		severity = SeverityHigh
+	}
+
+	top := b.categories()[0]
+	return []Finding{{
+		ID:           FindingMemoryOverhead,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("dataset is only %.1f%% of used memory %s; largest overhead is %s at %s",
+			b.DatasetPercent, FormatBytes(b.TotalAllocated), top.Name, FormatBytes(top.Bytes)),
+		Metadata: map[string]any{
+			"dataset_percent":      b.DatasetPercent,
+			"largest_overhead":     top.Name,
+			"largest_overhead_mem": top.Bytes,
+			"recommendation":       overheadAdvice[top.Name],
+			"breakdown":            b,
+		},
+	}}, nil
+}
@@ -0,0 +1,101 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func memoryStatsFixture(total int64, datasetPercent string, backlog int64) map[string]any {
+	return map[string]any{
+		"peak.allocated":      total,
+		"total.allocated":     total,
+		"startup.allocated":   int64(1000000),
+		"replication.backlog": backlog,
+		"clients.slaves":      int64(0),
+		"clients.normal":      int64(200000),
+		"aof.buffer":          int64(0),
+		"lua.caches":          int64(5000),
+		"db.0":                []any{"overhead.hashtable.main", int64(4000000), "overhead.hashtable.expires", int64(100000)},
+		"overhead.total":      int64(1000000) + backlog + 4305000,
+		"keys.count":          int64(50000),
+		"keys.bytes-per-key":  int64(120),
+		"dataset.bytes":       int64(6000000),
+		"dataset.percentage":  datasetPercent,
+	}
+}
+
+func TestMemoryOverheadScanner_Name(t *testing.T) {
+	s := &MemoryOverheadScanner{}
+	if s.Name() != "memory_overhead" {
+		t.Errorf("expected name 'memory_overhead', got %q", s.Name())
+	}
+}
+
+func TestMemoryOverheadScanner_LargeOverhead(t *testing.T) {
+	mock := newMockClient()
+	mock.memoryStats = memoryStatsFixture(300*1024*1024, "5.2", 256*1024*1024)
+	cfg := AuditConfig{Addr: "localhost:6379", inventory: &inventory{data: make(map[string]any)}}
+
+	s := &MemoryOverheadScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d", len(findings))
+	}
+	f := findings[0]
+	if f.ID != FindingMemoryOverhead || f.Severity != SeverityHigh {
+		t.Errorf("expected high MEMORY_OVERHEAD, got %s %s", f.Severity, f.ID)
+	}
+	if f.Metadata["largest_overhead"] != "replication_backlog" {
+		t.Errorf("expected replication_backlog as largest overhead, got %v", f.Metadata["largest_overhead"])
+	}
+
+	b, ok := cfg.inventory.data["memory_stats"].(memoryBreakdown)
+	if !ok {
+		t.Fatal("expected memory_stats inventory")
+	}
+	if b.DBHashtables["db.0"].Main != 4000000 || b.DatasetPercent != 5.2 || b.LuaCaches != 5000 {
+		t.Errorf("unexpected breakdown: %+v", b)
+	}
+}
+
+func TestMemoryOverheadScanner_SmallInstance(t *testing.T) {
+	mock := newMockClient()
+	mock.memoryStats = memoryStatsFixture(8*1024*1024, "5.2", 1024*1024)
+
+	s := &MemoryOverheadScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings below the size guard, got %d", len(findings))
+	}
+}
+
+func TestMemoryOverheadScanner_MostlyDataset(t *testing.T) {
+	mock := newMockClient()
+	mock.memoryStats = memoryStatsFixture(300*1024*1024, "91.0", 1024*1024)
+
+	s := &MemoryOverheadScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d", len(findings))
+	}
+}
+
+func TestMemoryOverheadScanner_Error(t *testing.T) {
+	mock := newMockClient()
+	mock.memStatsErr = errors.New("ERR unknown command")
+
+	s := &MemoryOverheadScanner{}
+	if _, err := s.Audit(context.Background(), mock, AuditConfig{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -3,25 +3,49 @@ package redis
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 )
 
 // mockClient implements RedisClient for testing.
 type mockClient struct {
-	infoResponses map[string]string
-	scanKeys      []string
-	idleTimes     map[string]time.Duration
-	memoryUsages  map[string]int64
-	slowLog       []SlowLogEntry
-	configValues  map[string]map[string]string
-	dbSize        int64
-	pingErr       error
-	infoErr       error
-	scanErr       error
-	idleTimeErr   error
-	memUsageErr   error
-	slowLogErr    error
-	configErr     error
+	infoResponses  map[string]string
+	scanKeys       []string
+	idleTimes      map[string]time.Duration
+	freqs          map[string]int64
+	memoryUsages   map[string]int64
+	keyTypes       map[string]string
+	values         map[string]string
+	hashes         map[string]map[string]string
+	slowLog        []SlowLogEntry
+	configValues   map[string]map[string]string
+	dbSize         int64
+	aclList        []string
+	aclLog         []ACLLogEntry
+	clientList     string
+	clientListErr  error
+	commands       map[string][]string
+	latencyLatest  []LatencyEvent
+	latencyHistory map[string][]LatencySample
+	latencyDoctor  string
+	memoryStats    map[string]any
+	mallocStats    string
+	modules        []ModuleInfo
+	ftIndexes      map[string]map[string]any
+	tsInfo         map[string]map[string]any
+	moduleErr      error
+	clusterInfo    string
+	clusterNodes   string
+	pingErr        error
+	infoErr        error
+	scanErr        error
+	idleTimeErr    error
+	memUsageErr    error
+	slowLogErr     error
+	configErr      error
+	aclErr         error
+	latencyErr     error
+	memStatsErr    error
 }
 
 func newMockClient() *mockClient {
@@ -29,6 +53,9 @@ func newMockClient() *mockClient {
 		infoResponses: make(map[string]string),
 		idleTimes:     make(map[string]time.Duration),
 		memoryUsages:  make(map[string]int64),
+		keyTypes:      make(map[string]string),
+		values:        make(map[string]string),
+		hashes:        make(map[string]map[string]string),
 		configValues:  make(map[string]map[string]string),
 	}
 }
@@ -59,6 +86,13 @@ func (m *mockClient) Scan(_ context.Context, cursor uint64, _ string, _ int64) (
 	return m.scanKeys, 0, nil
 }
 
+func (m *mockClient) ObjectFreq(_ context.Context, key string) (int64, error) {
+	if freq, ok := m.freqs[key]; ok {
+		return freq, nil
+	}
+	return 0, fmt.Errorf("key not found: %s", key)
+}
+
 func (m *mockClient) ObjectIdleTime(_ context.Context, key string) (time.Duration, error) {
 	if m.idleTimeErr != nil {
 		return 0, m.idleTimeErr
@@ -79,6 +113,49 @@ func (m *mockClient) MemoryUsage(_ context.Context, key string) (int64, error) {
 	return 0, fmt.Errorf("key not found: %s", key)
 }
 
+func (m *mockClient) Type(_ context.Context, key string) (string, error) {
+	if t, ok := m.keyTypes[key]; ok {
+		return t, nil
+	}
+	if _, ok := m.values[key]; ok {
+		return "string", nil
+	}
+	if _, ok := m.hashes[key]; ok {
+		return "hash", nil
+	}
+	return "none", nil
+}
+
+func (m *mockClient) StrLen(_ context.Context, key string) (int64, error) {
+	return int64(len(m.values[key])), nil
+}
+
+func (m *mockClient) GetRange(_ context.Context, key string, start, end int64) (string, error) {
+	v := m.values[key]
+	if start >= int64(len(v)) {
+		return "", nil
+	}
+	if end >= int64(len(v)) {
+		end = int64(len(v)) - 1
+	}
+	return v[start : end+1], nil
+}
+
+func (m *mockClient) HScan(_ context.Context, key string, cursor uint64, _ string, _ int64) ([]string, uint64, error) {
+	if cursor > 0 {
+		return nil, 0, nil
+	}
+	fields := make([]string, 0, len(m.hashes[key])*2)
+	for field, value := range m.hashes[key] {
+		fields = append(fields, field, value)
+	}
+	return fields, 0, nil
+}
+
+func (m *mockClient) HLen(_ context.Context, key string) (int64, error) {
+	return int64(len(m.hashes[key])), nil
+}
+
 func (m *mockClient) SlowLogGet(_ context.Context, _ int64) ([]SlowLogEntry, error) {
 	if m.slowLogErr != nil {
 		return nil, m.slowLogErr
@@ -100,6 +177,95 @@ func (m *mockClient) DBSize(_ context.Context) (int64, error) {
 	return m.dbSize, nil
 }
 
+func (m *mockClient) ACLList(_ context.Context) ([]string, error) {
+	if m.aclErr != nil {
+		return nil, m.aclErr
+	}
+	return m.aclList, nil
+}
+
+func (m *mockClient) ACLLog(_ context.Context, _ int64) ([]ACLLogEntry, error) {
+	if m.aclErr != nil {
+		return nil, m.aclErr
+	}
+	return m.aclLog, nil
+}
+
+func (m *mockClient) ClientList(_ context.Context) (string, error) {
+	return m.clientList, m.clientListErr
+}
+
+func (m *mockClient) Commands(_ context.Context) (map[string][]string, error) {
+	return m.commands, nil
+}
+
+func (m *mockClient) LatencyLatest(_ context.Context) ([]LatencyEvent, error) {
+	if m.latencyErr != nil {
+		return nil, m.latencyErr
+	}
+	return m.latencyLatest, nil
+}
+
+func (m *mockClient) LatencyHistory(_ context.Context, event string) ([]LatencySample, error) {
+	if m.latencyErr != nil {
+		return nil, m.latencyErr
+	}
+	return m.latencyHistory[event], nil
+}
+
+func (m *mockClient) LatencyDoctor(_ context.Context) (string, error) {
+	if m.latencyDoctor == "" {
+		return "", fmt.Errorf("ERR unknown subcommand 'doctor'")
+	}
+	return m.latencyDoctor, nil
+}
+
+func (m *mockClient) MemoryStats(_ context.Context) (map[string]any, error) {
+	if m.memStatsErr != nil {
+		return nil, m.memStatsErr
+	}
+	return m.memoryStats, nil
+}
+
+func (m *mockClient) ModuleList(_ context.Context) ([]ModuleInfo, error) {
+	return m.modules, m.moduleErr
+}
+
+func (m *mockClient) FTList(_ context.Context) ([]string, error) {
+	var names []string
+	for name := range m.ftIndexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *mockClient) FTInfo(_ context.Context, index string) (map[string]any, error) {
+	if info, ok := m.ftIndexes[index]; ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("Unknown index name")
+}
+
+func (m *mockClient) TSInfo(_ context.Context, key string) (map[string]any, error) {
+	if info, ok := m.tsInfo[key]; ok {
+		return info, nil
+	}
+	return nil, fmt.Errorf("TSDB: the key does not exist")
+}
+
+func (m *mockClient) ClusterInfo(_ context.Context) (string, error) {
+	return m.clusterInfo, nil
+}
+
+func (m *mockClient) ClusterNodes(_ context.Context) (string, error) {
+	return m.clusterNodes, nil
+}
+
+func (m *mockClient) MallocStats(_ context.Context) (string, error) {
+	return m.mallocStats, nil
+}
+
 func (m *mockClient) Close() error {
 	return nil
 }
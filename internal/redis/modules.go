@@ -0,0 +1,303 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// searchIndexMinBytes skips the index memory check for small indexes.
+	searchIndexMinBytes = 256 << 20 // 256 MB
+	// searchIndexShare and searchIndexHighShare are the shares of
+	// used_memory held by one index reported as medium and high.
+	searchIndexShare     = 0.25
+	searchIndexHighShare = 0.5
+	// searchDeletedRatio is the share of document IDs no longer backed by a
+	// document above which an index is reported; searchDeletedMinDocs skips
+	// indexes too small for the ratio to matter.
+	searchDeletedRatio   = 0.5
+	searchDeletedMinDocs = 10000
+	// timeSeriesExamples caps the example keys kept per namespace.
+	timeSeriesExamples = 5
+)
+
+// searchMemoryFields are the FT.INFO size fields summed when the server
+// does not report total_index_memory_sz_mb.
+var searchMemoryFields = []string{
+	"inverted_sz_mb",
+	"offset_vectors_sz_mb",
+	"doc_table_size_mb",
+	"sortable_values_size_mb",
+	"key_table_size_mb",
+	"vector_index_sz_mb",
+}
+
+// ModuleScanner lists loaded modules and audits the data structures of the
+// ones it knows: RediSearch indexes and RedisTimeSeries keys.
+type ModuleScanner struct{}
+
+func (s *ModuleScanner) Name() string { return "modules" }
+
+// Applicable requires MODULE LIST (Redis 4.0+).
+func (s *ModuleScanner) Applicable(p *Profile) (string, bool) { return p.require(CapModules) }
+
+func (s *ModuleScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	modules, err := client.ModuleList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("module list: %w", err)
+	}
+
+	loaded := make(map[string]bool, len(modules))
+	list := make([]map[string]any, 0, len(modules))
+	for _, m := range modules {
+		loaded[strings.ToLower(m.Name)] = true
+		list = append(list, map[string]any{
+			"name":    m.Name,
+			"version": formatModuleVersion(m.Version),
+			"path":    m.Path,
+		})
+	}
+	cfg.inventory.set("modules", list)
+
+	var findings []Finding
+	if loaded["search"] {
+		f, err := s.auditSearch(ctx, client, cfg)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f...)
+	}
+	if loaded["timeseries"] {
+		f, err := s.auditTimeSeries(ctx, client, cfg)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, f...)
+	}
+	return findings, nil
+}
+
+// formatModuleVersion renders MODULE LIST's encoded version (20811) as
+// major.minor.patch.
+func formatModuleVersion(v int64) string {
+	return fmt.Sprintf("%d.%d.%d", v/10000, v/100%100, v%100)
+}
+
+// searchIndex is the part of an FT.INFO reply the checks read.
+type searchIndex struct {
+	Name     string
+	Bytes    int64
+	NumDocs  int64
+	MaxDocID int64
+	Uses     int64
+	HasUses  bool
+}
+
+func parseSearchIndex(name string, info map[string]any) searchIndex {
+	idx := searchIndex{
+		Name:     name,
+		NumDocs:  replyInt(info["num_docs"]),
+		MaxDocID: replyInt(info["max_doc_id"]),
+	}
+	if uses, ok := info["number_of_uses"]; ok {
+		idx.Uses = replyInt(uses)
+		idx.HasUses = true
+	}
+	mb, ok := info["total_index_memory_sz_mb"]
+	if ok {
+		idx.Bytes = int64(replyFloat(mb) * (1 << 20))
+	} else {
+		var total float64
+		for _, field := range searchMemoryFields {
+			total += replyFloat(info[field])
+		}
+		idx.Bytes = int64(total * (1 << 20))
+	}
+	return idx
+}
+
+func (s *ModuleScanner) auditSearch(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	names, err := client.FTList(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ft._list: %w", err)
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	memoryRaw, err := client.Info(ctx, "memory")
+	if err != nil {
+		return nil, fmt.Errorf("info memory: %w", err)
+	}
+	usedMemory := infoInt(ParseInfo(memoryRaw), "used_memory")
+
+	serverRaw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	restarted := countersFor(cfg, ParseInfo(serverRaw)).RecentlyRestarted()
+
+	var findings []Finding
+	for _, name := range names {
+		info, err := client.FTInfo(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("ft.info %s: %w", name, err)
+		}
+		idx := parseSearchIndex(name, info)
+
+		if f, ok := s.indexMemory(cfg, idx, usedMemory); ok {
+			findings = append(findings, f)
+		}
+		if f, ok := s.deletedDocs(cfg, idx); ok {
+			findings = append(findings, f)
+		}
+		// number_of_uses resets on restart, so a fresh server has not had
+		// time to query its indexes.
+		if idx.HasUses && idx.Uses == 0 && !restarted {
+			findings = append(findings, Finding{
+				ID:           FindingSearchIndexUnused,
+				Severity:     SeverityLow,
+				ResourceType: "SearchIndex",
+				ResourceID:   name,
+				Message:      fmt.Sprintf("search index %s has not been queried since the server started (%d docs, %s)", name, idx.NumDocs, FormatBytes(idx.Bytes)),
+				Metadata: map[string]any{
+					"index":          name,
+					"number_of_uses": idx.Uses,
+					"num_docs":       idx.NumDocs,
+					"index_memory":   idx.Bytes,
+					"recommendation": "drop the index with FT.DROPINDEX if no application queries it; it is still updated on every write",
+				},
+			})
+		}
+	}
+	return findings, nil
+}
+
+func (s *ModuleScanner) indexMemory(cfg AuditConfig, idx searchIndex, usedMemory int64) (Finding, bool) {
+	if idx.Bytes < searchIndexMinBytes || usedMemory == 0 {
+		return Finding{}, false
+	}
+	share := float64(idx.Bytes) / float64(usedMemory)
+	var severity Severity
+	switch {
+	case share >= searchIndexHighShare:
+		severity = SeverityHigh
+	case share >= searchIndexShare:
+		severity = SeverityMedium
+	default:
+		return Finding{}, false
+	}
+	return Finding{
+		ID:           FindingSearchIndexMemory,
+		Severity:     severity,
+		ResourceType: "SearchIndex",
+		ResourceID:   idx.Name,
+		Message: fmt.Sprintf("search index %s uses %s, %.0f%% of used memory %s",
+			idx.Name, FormatBytes(idx.Bytes), share*100, FormatBytes(usedMemory)),
+		Metadata: map[string]any{
+			"index":          idx.Name,
+			"index_memory":   idx.Bytes,
+			"used_memory":    usedMemory,
+			"share_percent":  share * 100,
+			"num_docs":       idx.NumDocs,
+			"recommendation": "index fewer fields, drop SORTABLE where it is not needed, or use NOOFFSETS/NOFREQS for text fields",
+		},
+	}, true
+}
+
+func (s *ModuleScanner) deletedDocs(cfg AuditConfig, idx searchIndex) (Finding, bool) {
+	if idx.MaxDocID < searchDeletedMinDocs || idx.NumDocs >= idx.MaxDocID {
+		return Finding{}, false
+	}
+	ratio := float64(idx.MaxDocID-idx.NumDocs) / float64(idx.MaxDocID)
+	if ratio < searchDeletedRatio {
+		return Finding{}, false
+	}
+	return Finding{
+		ID:           FindingSearchDeletedDocs,
+		Severity:     SeverityLow,
+		ResourceType: "SearchIndex",
+		ResourceID:   idx.Name,
+		Message: fmt.Sprintf("search index %s has %d live documents out of %d document IDs (%.0f%% deleted or replaced)",
+			idx.Name, idx.NumDocs, idx.MaxDocID, ratio*100),
+		Metadata: map[string]any{
+			"index":          idx.Name,
+			"num_docs":       idx.NumDocs,
+			"max_doc_id":     idx.MaxDocID,
+			"deleted_ratio":  ratio,
+			"index_memory":   idx.Bytes,
+			"recommendation": "check the gc_stats of FT.INFO; heavy update or delete churn leaves garbage the index GC must reclaim, and rebuilding the index compacts it",
+		},
+	}, true
+}
+
+// unboundedSeries aggregates time series without retention per namespace.
+type unboundedSeries struct {
+	count    int
+	samples  int64
+	memory   int64
+	examples []string
+}
+
+func (s *ModuleScanner) auditTimeSeries(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	groups := make(map[string]*unboundedSeries)
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		keyType, err := client.Type(ctx, key)
+		if err != nil || keyType != "TSDB-TYPE" {
+			return true
+		}
+		info, err := client.TSInfo(ctx, key)
+		if err != nil {
+			return true
+		}
+		if replyInt(info["retentionTime"]) != 0 {
+			return true
+		}
+		ns := keyNamespace(key)
+		g, ok := groups[ns]
+		if !ok {
+			g = &unboundedSeries{}
+			groups[ns] = g
+		}
+		g.count++
+		g.samples += replyInt(info["totalSamples"])
+		g.memory += replyInt(info["memoryUsage"])
+		if len(g.examples) < timeSeriesExamples {
+			g.examples = append(g.examples, key)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, 0, len(groups))
+	for ns := range groups {
+		namespaces = append(namespaces, ns)
+	}
+	sort.Strings(namespaces)
+
+	findings := make([]Finding, 0, len(namespaces))
+	for _, ns := range namespaces {
+		g := groups[ns]
+		findings = append(findings, Finding{
+			ID:           FindingTimeSeriesUnbounded,
+			Severity:     SeverityMedium,
+			ResourceType: "TimeSeries",
+			ResourceID:   ns,
+			Message: fmt.Sprintf("%d sampled time series in namespace %s have no retention and grow without bound (%d samples, %s)",
+				g.count, ns, g.samples, FormatBytes(g.memory)),
+			Metadata: map[string]any{
+				"namespace":      ns,
+				"count":          g.count,
+				"total_samples":  g.samples,
+				"memory_usage":   g.memory,
+				"examples":       g.examples,
+				"recommendation": "set RETENTION with TS.ALTER, or DUPLICATE_POLICY and compaction rules to downsample old data",
+			},
+		})
+	}
+	return findings, nil
+}
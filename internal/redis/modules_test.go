@@ -0,0 +1,142 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestModuleScanner_Name(t *testing.T) {
+	s := &ModuleScanner{}
+	if s.Name() != "modules" {
+		t.Errorf("expected name 'modules', got %q", s.Name())
+	}
+}
+
+func TestFormatModuleVersion(t *testing.T) {
+	if got := formatModuleVersion(20811); got != "2.8.11" {
+		t.Errorf("expected 2.8.11, got %s", got)
+	}
+	if got := formatModuleVersion(11200); got != "1.12.0" {
+		t.Errorf("expected 1.12.0, got %s", got)
+	}
+}
+
+func searchMock() *mockClient {
+	mock := newMockClient()
+	mock.modules = []ModuleInfo{{Name: "search", Version: 21005}}
+	mock.infoResponses["memory"] = "# Memory\nused_memory:1073741824\n"
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:864000\n"
+	return mock
+}
+
+func TestModuleScanner_SearchIndexes(t *testing.T) {
+	mock := searchMock()
+	mock.ftIndexes = map[string]map[string]any{
+		// 600 MB of a 1 GB dataset.
+		"idx:big": {"num_docs": int64(500000), "max_doc_id": int64(510000), "number_of_uses": int64(42), "total_index_memory_sz_mb": "600"},
+		// RESP2 replies send numbers as strings; the size fields are summed.
+		"idx:churn": {"num_docs": "20000", "max_doc_id": "100000", "number_of_uses": "7", "inverted_sz_mb": "1.5", "doc_table_size_mb": "0.5"},
+		"idx:idle":  {"num_docs": int64(100), "max_doc_id": int64(100), "number_of_uses": int64(0), "total_index_memory_sz_mb": "0.1"},
+	}
+
+	findings, err := (&ModuleScanner{}).Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+
+	mem := byID[FindingSearchIndexMemory]
+	if len(mem) != 1 || mem[0].ResourceID != "idx:big" || mem[0].Severity != SeverityHigh {
+		t.Fatalf("expected high SEARCH_INDEX_MEMORY for idx:big, got %v", mem)
+	}
+
+	deleted := byID[FindingSearchDeletedDocs]
+	if len(deleted) != 1 || deleted[0].ResourceID != "idx:churn" {
+		t.Fatalf("expected SEARCH_INDEX_DELETED_DOCS for idx:churn, got %v", deleted)
+	}
+	if got := deleted[0].Metadata["index_memory"]; got != int64(2<<20) {
+		t.Errorf("expected summed index memory of 2 MB, got %v", got)
+	}
+
+	unused := byID[FindingSearchIndexUnused]
+	if len(unused) != 1 || unused[0].ResourceID != "idx:idle" {
+		t.Fatalf("expected SEARCH_INDEX_UNUSED for idx:idle, got %v", unused)
+	}
+}
+
+func TestModuleScanner_UnusedAfterRestart(t *testing.T) {
+	mock := searchMock()
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:120\n"
+	mock.ftIndexes = map[string]map[string]any{
+		"idx:idle": {"num_docs": int64(100), "max_doc_id": int64(100), "number_of_uses": int64(0)},
+	}
+
+	findings, err := (&ModuleScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected no findings two minutes after a restart, got %v", findings)
+	}
+}
+
+func TestModuleScanner_TimeSeries(t *testing.T) {
+	mock := newMockClient()
+	mock.modules = []ModuleInfo{{Name: "timeseries", Version: 11012}}
+	mock.scanKeys = []string{"temp:1", "temp:2", "cpu:1", "user:1"}
+	mock.keyTypes = map[string]string{"temp:1": "TSDB-TYPE", "temp:2": "TSDB-TYPE", "cpu:1": "TSDB-TYPE", "user:1": "hash"}
+	mock.tsInfo = map[string]map[string]any{
+		"temp:1": {"retentionTime": int64(0), "totalSamples": int64(1000), "memoryUsage": int64(4096)},
+		"temp:2": {"retentionTime": int64(0), "totalSamples": int64(500), "memoryUsage": int64(2048)},
+		"cpu:1":  {"retentionTime": int64(86400000), "totalSamples": int64(100), "memoryUsage": int64(1024)},
+	}
+
+	findings, err := (&ModuleScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Fatalf("expected 1 finding, got %d: %v", len(findings), findings)
+	}
+	f := findings[0]
+	if f.ID != FindingTimeSeriesUnbounded || f.ResourceID != "temp" {
+		t.Errorf("expected TIMESERIES_UNBOUNDED for namespace temp, got %s %s", f.ID, f.ResourceID)
+	}
+	if f.Metadata["count"] != 2 || f.Metadata["total_samples"] != int64(1500) || f.Metadata["memory_usage"] != int64(6144) {
+		t.Errorf("unexpected metadata: %v", f.Metadata)
+	}
+}
+
+func TestModuleScanner_NoModules(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"temp:1"}
+	mock.keyTypes = map[string]string{"temp:1": "TSDB-TYPE"}
+
+	findings, err := (&ModuleScanner{}).Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected no findings without modules, got %v", findings)
+	}
+}
+
+func TestModuleScanner_Error(t *testing.T) {
+	mock := newMockClient()
+	mock.moduleErr = errors.New("ERR unknown command 'MODULE'")
+
+	if _, err := (&ModuleScanner{}).Audit(context.Background(), mock, AuditConfig{}); err == nil {
+		t.Error("expected error from MODULE LIST")
+	}
+}
+
+func TestModuleScanner_Applicable(t *testing.T) {
+	p := &Profile{Unavailable: map[Capability]string{CapModules: "requires Redis 4.0 or later"}}
+	if _, ok := (&ModuleScanner{}).Applicable(p); ok {
+		t.Error("expected modules auditor to be skipped without MODULE LIST")
+	}
+	if _, ok := (&ModuleScanner{}).Applicable(nil); !ok {
+		t.Error("expected modules auditor to apply with no profile")
+	}
+}
@@ -0,0 +1,194 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+const (
+	maxKeyLengthRule  = "max_key_length"
+	maxNamingExamples = 5
+	// keyNameAvgBytes and keyNameMinBytes report a namespace whose names
+	// average keyNameAvgBytes or more once they add up to keyNameMinBytes;
+	// keyNameTotalBytes reports one whose names add up to that much at any
+	// length. Both totals are estimated across the keyspace.
+	keyNameAvgBytes   = 64
+	keyNameMinBytes   = 1 << 20  // 1 MB
+	keyNameTotalBytes = 64 << 20 // 64 MB
+	// keyNameInventoryMax caps the namespaces listed in the inventory.
+	keyNameInventoryMax = 50
+)
+
+// NamingRule is a regular expression every sampled key name must match.
+type NamingRule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// NamingScanner measures how many bytes key names take per namespace and
+// lints sampled key names against the naming rules and maximum key length,
+// when they are configured.
+type NamingScanner struct{}
+
+func (s *NamingScanner) Name() string { return "naming" }
+
+type namingViolations struct {
+	count    int
+	examples []string
+}
+
+type nameBytes struct {
+	keys     int
+	bytes    int64
+	examples []string
+}
+
+func (s *NamingScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	violations := make(map[string]*namingViolations)
+	namespaces := make(map[string]*nameBytes)
+	walked := 0
+
+	violate := func(rule, key string) {
+		v, ok := violations[rule]
+		if !ok {
+			v = &namingViolations{}
+			violations[rule] = v
+		}
+		v.count++
+		if len(v.examples) < maxNamingExamples {
+			v.examples = append(v.examples, key)
+		}
+	}
+
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		walked++
+
+		ns := keyNamespace(key)
+		nb, ok := namespaces[ns]
+		if !ok {
+			nb = &nameBytes{}
+			namespaces[ns] = nb
+		}
+		nb.keys++
+		nb.bytes += int64(len(key))
+		if len(nb.examples) < maxNamingExamples {
+			nb.examples = append(nb.examples, key)
+		}
+
+		if cfg.MaxKeyLength > 0 && len(key) > cfg.MaxKeyLength {
+			violate(maxKeyLengthRule, key)
+		}
+		for _, rule := range cfg.NamingRules {
+			if !rule.Pattern.MatchString(key) {
+				violate(rule.Name, key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if walked == 0 {
+		return nil, nil
+	}
+
+	var findings []Finding
+
+	if v, ok := violations[maxKeyLengthRule]; ok {
+		findings = append(findings, namingFinding(maxKeyLengthRule, fmt.Sprintf("length <= %d", cfg.MaxKeyLength), v, walked))
+	}
+	for _, rule := range cfg.NamingRules {
+		if v, ok := violations[rule.Name]; ok {
+			findings = append(findings, namingFinding(rule.Name, rule.Pattern.String(), v, walked))
+		}
+	}
+
+	// Scale sampled name bytes up to the whole keyspace when SCAN stopped short.
+	scale := 1.0
+	if dbSize, err := client.DBSize(ctx); err == nil && dbSize > int64(walked) {
+		scale = float64(dbSize) / float64(walked)
+	}
+
+	nsNames := make([]string, 0, len(namespaces))
+	for ns := range namespaces {
+		nsNames = append(nsNames, ns)
+	}
+	sort.Slice(nsNames, func(i, j int) bool {
+		bi, bj := namespaces[nsNames[i]].bytes, namespaces[nsNames[j]].bytes
+		if bi != bj {
+			return bi > bj
+		}
+		return nsNames[i] < nsNames[j]
+	})
+
+	inventory := make([]map[string]any, 0, min(len(nsNames), keyNameInventoryMax))
+	for _, ns := range nsNames {
+		nb := namespaces[ns]
+		avg := float64(nb.bytes) / float64(nb.keys)
+		estimated := int64(float64(nb.bytes) * scale)
+		if len(inventory) < keyNameInventoryMax {
+			inventory = append(inventory, map[string]any{
+				"namespace":            ns,
+				"keys_sampled":         nb.keys,
+				"avg_key_length":       avg,
+				"estimated_name_bytes": estimated,
+			})
+		}
+
+		var threshold string
+		switch {
+		case estimated >= keyNameTotalBytes:
+			threshold = "total_bytes"
+		case avg >= keyNameAvgBytes && estimated >= keyNameMinBytes:
+			threshold = "avg_key_length"
+		default:
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:           FindingKeyNameBytes,
+			Severity:     SeverityLow,
+			ResourceType: "Namespace",
+			ResourceID:   ns,
+			Message: fmt.Sprintf("namespace %q key names average %.0f bytes and take an estimated %s across the keyspace",
+				ns, avg, FormatBytes(estimated)),
+			Metadata: map[string]any{
+				"namespace":            ns,
+				"keys_sampled":         nb.keys,
+				"sampled_name_bytes":   nb.bytes,
+				"avg_key_length":       avg,
+				"estimated_name_bytes": estimated,
+				"estimated_name_human": FormatBytes(estimated),
+				"threshold":            threshold,
+				"example_keys":         nb.examples,
+				"recommendation":       "shorten key prefixes or move repeated parts of the name into the value, e.g. hash fields",
+			},
+		})
+	}
+	cfg.inventory.set("key_names", map[string]any{
+		"keys_sampled": walked,
+		"namespaces":   inventory,
+	})
+
+	return findings, nil
+}
+
+func namingFinding(rule, pattern string, v *namingViolations, sampled int) Finding {
+	return Finding{
+		ID:           FindingKeyNamingViolation,
+		Severity:     SeverityLow,
+		ResourceType: "NamingRule",
+		ResourceID:   rule,
+		Message: fmt.Sprintf("%d of %d sampled keys violate naming rule %q (%s)",
+			v.count, sampled, rule, pattern),
+		Metadata: map[string]any{
+			"rule":              rule,
+			"pattern":           pattern,
+			"violations":        v.count,
+			"keys_sampled":      sampled,
+			"violation_percent": float64(v.count) / float64(sampled) * 100,
+			"example_keys":      v.examples,
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func TestNamingScanner_Name(t *testing.T) {
+	s := &NamingScanner{}
+	if s.Name() != "naming" {
+		t.Errorf("expected name 'naming', got %q", s.Name())
+	}
+}
+
+func TestNamingScanner_NoRules(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"Anything Goes"}
+
+	cfg := AuditConfig{inventory: &inventory{data: make(map[string]any)}}
+
+	s := &NamingScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings without rules, got %d", len(findings))
+	}
+	inv, ok := cfg.inventory.data["key_names"].(map[string]any)
+	if !ok {
+		t.Fatal("expected key name bytes in the inventory without rules")
+	}
+	if ns := inv["namespaces"].([]map[string]any); len(ns) != 1 || ns[0]["estimated_name_bytes"] != int64(13) {
+		t.Errorf("unexpected key name inventory: %v", inv)
+	}
+}
+
+func TestNamingScanner_Violations(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{
+		"auth:session:1",
+		"auth:session:2",
+		"Auth:Session:3",
+		"legacy_key",
+		"auth:session:" + string(make([]byte, 40)),
+	}
+	mock.dbSize = 10
+
+	cfg := AuditConfig{
+		MaxKeyLength: 32,
+		NamingRules: []NamingRule{
+			{Name: "service-entity-id", Pattern: regexp.MustCompile(`^[^:]+:[^:]+:.+$`)},
+			{Name: "lowercase", Pattern: regexp.MustCompile(`^[^A-Z]*$`)},
+		},
+	}
+
+	s := &NamingScanner{}
+	findings, err := s.Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	violations := make(map[string]Finding)
+	var nameBytes []Finding
+	for _, f := range findings {
+		switch f.ID {
+		case FindingKeyNamingViolation:
+			violations[f.ResourceID] = f
+		case FindingKeyNameBytes:
+			nameBytes = append(nameBytes, f)
+		}
+	}
+
+	tests := map[string]int{
+		"max_key_length":    1,
+		"service-entity-id": 1,
+		"lowercase":         1,
+	}
+	for rule, want := range tests {
+		f, ok := violations[rule]
+		if !ok {
+			t.Errorf("expected violation finding for rule %q", rule)
+			continue
+		}
+		if f.Metadata["violations"] != want {
+			t.Errorf("rule %q: expected %d violations, got %v", rule, want, f.Metadata["violations"])
+		}
+	}
+
+	if len(nameBytes) != 0 {
+		t.Errorf("expected no KEY_NAME_BYTES for short names, got %v", nameBytes)
+	}
+}
+
+func TestNamingScanner_NameBytesThresholds(t *testing.T) {
+	mock := newMockClient()
+	for i := 0; i < 50; i++ {
+		// 80-byte names: over the average length threshold.
+		mock.scanKeys = append(mock.scanKeys, fmt.Sprintf("verbose:%072d", i))
+		mock.scanKeys = append(mock.scanKeys, fmt.Sprintf("s:%d", i))
+	}
+	// 100 keys sampled of 100,000: verbose estimates to 4 MB of names.
+	mock.dbSize = 100000
+	cfg := AuditConfig{inventory: &inventory{data: make(map[string]any)}}
+
+	findings, err := (&NamingScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingKeyNameBytes || findings[0].ResourceID != "verbose" {
+		t.Fatalf("expected KEY_NAME_BYTES for verbose only, got %v", findings)
+	}
+	f := findings[0]
+	if f.Metadata["threshold"] != "avg_key_length" || f.Metadata["estimated_name_bytes"] != int64(4000000) {
+		t.Errorf("unexpected metadata: %v", f.Metadata)
+	}
+	inv := cfg.inventory.data["key_names"].(map[string]any)
+	if ns := inv["namespaces"].([]map[string]any); len(ns) != 2 || ns[0]["namespace"] != "verbose" {
+		t.Errorf("expected both namespaces in the inventory, largest first, got %v", ns)
+	}
+}
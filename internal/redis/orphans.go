@@ -0,0 +1,85 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// OrphanScanner reports sampled namespaces whose keys match no entry in the
+// ownership map, typically left behind by retired services. It does nothing
+// when no ownership map is configured.
+type OrphanScanner struct{}
+
+func (s *OrphanScanner) Name() string { return "orphans" }
+
+type orphanStats struct {
+	sampled  int
+	unowned  int
+	examples []string
+}
+
+func (s *OrphanScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	if cfg.Owners == nil {
+		return nil, nil
+	}
+
+	stats := make(map[string]*orphanStats)
+	walked := 0
+
+	err := forEachSampledKey(ctx, client, cfg.SampleSize, func(key string) bool {
+		walked++
+		ns := keyNamespace(key)
+		st, ok := stats[ns]
+		if !ok {
+			st = &orphanStats{}
+			stats[ns] = st
+		}
+		st.sampled++
+		if _, owned := cfg.Owners.Match(key); !owned {
+			st.unowned++
+			if len(st.examples) < 5 {
+				st.examples = append(st.examples, key)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	scale := 1.0
+	if dbSize, err := client.DBSize(ctx); err == nil && walked > 0 && dbSize > int64(walked) {
+		scale = float64(dbSize) / float64(walked)
+	}
+
+	namespaces := make([]string, 0, len(stats))
+	for ns, st := range stats {
+		if st.unowned == st.sampled {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+
+	var findings []Finding
+	for _, ns := range namespaces {
+		st := stats[ns]
+		estimated := int64(float64(st.sampled) * scale)
+		findings = append(findings, Finding{
+			ID:           FindingOrphanedNamespace,
+			Severity:     SeverityMedium,
+			ResourceType: "Namespace",
+			ResourceID:   ns,
+			Message: fmt.Sprintf("namespace %q has no owner (%d sampled keys, ~%d keys total)",
+				ns, st.sampled, estimated),
+			Metadata: map[string]any{
+				"namespace":      ns,
+				"keys_sampled":   st.sampled,
+				"estimated_keys": estimated,
+				"example_keys":   st.examples,
+			},
+		})
+	}
+
+	return findings, nil
+}
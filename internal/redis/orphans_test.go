@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestOrphanScanner_Name(t *testing.T) {
+	s := &OrphanScanner{}
+	if s.Name() != "orphans" {
+		t.Errorf("expected name 'orphans', got %q", s.Name())
+	}
+}
+
+func TestOrphanScanner_NoOwners(t *testing.T) {
+	mock := newMockClient()
+	mock.scanKeys = []string{"anything:1"}
+
+	s := &OrphanScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings without ownership map, got %d", len(findings))
+	}
+}
+
+func TestOrphanScanner_FindsOrphans(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader("session:* @identity\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mock := newMockClient()
+	mock.scanKeys = []string{"session:1", "session:2", "oldsvc:1", "oldsvc:2", "standalone"}
+
+	s := &OrphanScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Owners: owners})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 orphaned namespaces, got %d", len(findings))
+	}
+	if findings[0].ResourceID != "(none)" || findings[1].ResourceID != "oldsvc" {
+		t.Errorf("expected orphans [(none) oldsvc], got [%s %s]", findings[0].ResourceID, findings[1].ResourceID)
+	}
+	if findings[1].ID != FindingOrphanedNamespace {
+		t.Errorf("expected finding ID %q, got %q", FindingOrphanedNamespace, findings[1].ID)
+	}
+	if findings[1].Metadata["keys_sampled"] != 2 {
+		t.Errorf("expected 2 sampled keys, got %v", findings[1].Metadata["keys_sampled"])
+	}
+}
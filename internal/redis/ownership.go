@@ -0,0 +1,165 @@
+package redis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Owner identifies the team and service responsible for a set of keys.
+type Owner struct {
+	Team    string `json:"team"`
+	Service string `json:"service,omitempty"`
+}
+
+// OwnerRule maps a key prefix or glob to its owner.
+type OwnerRule struct {
+	Pattern string
+	Owner   Owner
+	re      *regexp.Regexp
+}
+
+// Owners is a CODEOWNERS-style ownership map for key names. As in
+// CODEOWNERS, the last matching rule wins.
+type Owners struct {
+	Rules []OwnerRule
+}
+
+// ParseOwners reads an ownership file. Each non-comment line holds a
+// pattern, a team, and an optional service:
+//
+//	session:*   @identity   auth-api
+//	cart:       @commerce   cart-service
+//
+// Patterns containing * or ? are globs matched against the whole key;
+// other patterns are key prefixes.
+func ParseOwners(r io.Reader) (*Owners, error) {
+	owners := &Owners{}
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected \"<pattern> <team> [service]\"", lineNo)
+		}
+		rule := OwnerRule{
+			Pattern: fields[0],
+			Owner:   Owner{Team: fields[1]},
+			re:      ownerPatternRegexp(fields[0]),
+		}
+		if len(fields) > 2 {
+			rule.Owner.Service = fields[2]
+		}
+		owners.Rules = append(owners.Rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+func ownerPatternRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	if !strings.ContainsAny(pattern, "*?") {
+		// Plain patterns are prefixes.
+		b.WriteString(".*")
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// Match returns the owner of key, if any rule matches it.
+func (o *Owners) Match(key string) (Owner, bool) {
+	if o == nil {
+		return Owner{}, false
+	}
+	for i := len(o.Rules) - 1; i >= 0; i-- {
+		if o.Rules[i].re.MatchString(key) {
+			return o.Rules[i].Owner, true
+		}
+	}
+	return Owner{}, false
+}
+
+// AssignOwners sets Owner on every finding whose resource maps to keys
+// covered by the ownership map. Findings that list several keys get an owner
+// only when every key resolves to the same one; when they span teams, the
+// distinct owners are listed in the "owners" metadata instead.
+func AssignOwners(findings []Finding, owners *Owners) {
+	if owners == nil {
+		return
+	}
+	for i := range findings {
+		keys := ownershipKeys(findings[i])
+		if len(keys) == 0 {
+			continue
+		}
+		var matched []Owner
+		unowned := false
+		for _, key := range keys {
+			owner, ok := owners.Match(key)
+			if !ok {
+				unowned = true
+				continue
+			}
+			if !containsOwner(matched, owner) {
+				matched = append(matched, owner)
+			}
+		}
+		switch {
+		case len(matched) == 1 && !unowned:
+			o := matched[0]
+			findings[i].Owner = &o
+		case len(matched) > 1:
+			if findings[i].Metadata == nil {
+				findings[i].Metadata = make(map[string]any)
+			}
+			findings[i].Metadata["owners"] = matched
+		}
+	}
+}
+
+func containsOwner(owners []Owner, o Owner) bool {
+	for _, x := range owners {
+		if x == o {
+			return true
+		}
+	}
+	return false
+}
+
+// ownershipKeys returns the key names a finding covers: its listed example
+// keys, or the key or namespace it names.
+func ownershipKeys(f Finding) []string {
+	if keys, ok := f.Metadata["example_keys"].([]string); ok && len(keys) > 0 {
+		return keys
+	}
+	if keys, ok := f.Metadata["keys"].([]string); ok && len(keys) > 0 {
+		return keys
+	}
+	switch f.ResourceType {
+	case "Key":
+		return []string{f.ResourceID}
+	case "Namespace":
+		return []string{f.ResourceID + ":"}
+	}
+	return nil
+}
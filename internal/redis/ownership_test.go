@@ -0,0 +1,122 @@
+package redis
+
+import (
+	"strings"
+	"testing"
+)
+
+const testOwners = `# pattern      team        service
+session:*      @identity   auth-api
+cart:          @commerce   cart-service
+cart:legacy:*  @platform
+`
+
+func TestParseOwners(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader(testOwners))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(owners.Rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(owners.Rules))
+	}
+	if owners.Rules[2].Owner.Service != "" {
+		t.Errorf("expected empty service for third rule, got %q", owners.Rules[2].Owner.Service)
+	}
+}
+
+func TestParseOwners_Invalid(t *testing.T) {
+	if _, err := ParseOwners(strings.NewReader("lonely-pattern\n")); err == nil {
+		t.Error("expected error for line without team")
+	}
+}
+
+func TestOwnersMatch(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader(testOwners))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		key     string
+		team    string
+		matched bool
+	}{
+		{"session:abc", "@identity", true},
+		{"cart:42", "@commerce", true},
+		{"cart:legacy:7", "@platform", true},
+		{"cartography", "", false},
+		{"sessions", "", false},
+		{"billing:1", "", false},
+	}
+	for _, tt := range tests {
+		owner, ok := owners.Match(tt.key)
+		if ok != tt.matched || owner.Team != tt.team {
+			t.Errorf("Match(%q) = %q, %v; want %q, %v", tt.key, owner.Team, ok, tt.team, tt.matched)
+		}
+	}
+}
+
+func TestOwnersMatch_PrefixIsLiteral(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader("cart: @commerce\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := owners.Match("cartography"); ok {
+		t.Error("prefix 'cart:' must not match 'cartography'")
+	}
+}
+
+func TestAssignOwners(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader(testOwners))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := []Finding{
+		{ID: FindingIdleKey, ResourceType: "Key", ResourceID: "session:1"},
+		{ID: FindingBigKey, ResourceType: "Key", ResourceID: "unknown:1"},
+		{ID: FindingCompressibleValues, ResourceType: "Namespace", ResourceID: "cart"},
+		{ID: FindingHighFragmentation, ResourceType: "Redis", ResourceID: "localhost:6379"},
+	}
+	AssignOwners(findings, owners)
+
+	if findings[0].Owner == nil || findings[0].Owner.Team != "@identity" {
+		t.Errorf("expected @identity owner for session key, got %+v", findings[0].Owner)
+	}
+	if findings[1].Owner != nil {
+		t.Errorf("expected no owner for unknown key, got %+v", findings[1].Owner)
+	}
+	if findings[2].Owner == nil || findings[2].Owner.Service != "cart-service" {
+		t.Errorf("expected cart-service owner for cart namespace, got %+v", findings[2].Owner)
+	}
+	if findings[3].Owner != nil {
+		t.Errorf("expected no owner for instance finding, got %+v", findings[3].Owner)
+	}
+}
+
+func TestAssignOwners_MixedKeys(t *testing.T) {
+	owners, err := ParseOwners(strings.NewReader(testOwners))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings := []Finding{
+		{ID: FindingDuplicateValue, ResourceType: "ValueGroup", Metadata: map[string]any{"keys": []string{"cart:1", "cart:2"}}},
+		{ID: FindingDuplicateValue, ResourceType: "ValueGroup", Metadata: map[string]any{"keys": []string{"session:1", "cart:1"}}},
+		{ID: FindingDuplicateValue, ResourceType: "ValueGroup", Metadata: map[string]any{"keys": []string{"cart:1", "unknown:1"}}},
+	}
+	AssignOwners(findings, owners)
+
+	if findings[0].Owner == nil || findings[0].Owner.Team != "@commerce" {
+		t.Errorf("expected @commerce owner when every key is theirs, got %+v", findings[0].Owner)
+	}
+	if findings[1].Owner != nil {
+		t.Errorf("expected no owner for keys spanning teams, got %+v", findings[1].Owner)
+	}
+	if got, _ := findings[1].Metadata["owners"].([]Owner); len(got) != 2 || got[0].Team != "@identity" || got[1].Team != "@commerce" {
+		t.Errorf("expected both owners listed, got %v", findings[1].Metadata["owners"])
+	}
+	if findings[2].Owner != nil {
+		t.Errorf("expected no owner when a key is unowned, got %+v", findings[2].Owner)
+	}
+}
@@ -3,9 +3,26 @@ package redis
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
-// PersistenceScanner audits Redis persistence configuration.
+const (
+	// staleSaveAge is how long unsaved changes may sit before the last
+	// successful RDB save is considered stale; twice this is high severity.
+	staleSaveAge = 24 * time.Hour
+	// aofRewriteStuckSeconds flags an AOF rewrite running longer than this.
+	aofRewriteStuckSeconds = 3600
+	// fsyncAlwaysBusyOps is the ops/sec above which appendfsync always
+	// becomes a throughput bottleneck.
+	fsyncAlwaysBusyOps = 1000
+	// slowForkUsec and blockingForkUsec are fork durations that stall
+	// clients noticeably and severely.
+	slowForkUsec     = 500_000
+	blockingForkUsec = 2_000_000
+)
+
+// PersistenceScanner audits Redis persistence configuration and the health
+// of RDB saves, AOF writes, and forks.
 type PersistenceScanner struct{}
 
 func (s *PersistenceScanner) Name() string { return "persistence" }
@@ -13,19 +30,11 @@ func (s *PersistenceScanner) Name() string { return "persistence" }
 func (s *PersistenceScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
 	var findings []Finding
 
-	saveConfig, err := client.ConfigGet(ctx, "save")
-	if err != nil {
-		return nil, fmt.Errorf("config get save: %w", err)
-	}
-
-	aofConfig, err := client.ConfigGet(ctx, "appendonly")
+	saveValue, appendOnly, err := persistenceConfig(ctx, client, cfg)
 	if err != nil {
-		return nil, fmt.Errorf("config get appendonly: %w", err)
+		return nil, err
 	}
 
-	saveValue := saveConfig["save"]
-	appendOnly := aofConfig["appendonly"]
-
 	rdbDisabled := saveValue == ""
 	aofDisabled := appendOnly != "yes"
 
@@ -43,5 +52,159 @@ func (s *PersistenceScanner) Audit(ctx context.Context, client RedisClient, cfg
 		})
 	}
 
+	raw, err := client.Info(ctx, "persistence")
+	if err != nil {
+		return nil, fmt.Errorf("info persistence: %w", err)
+	}
+	persistence := ParseInfo(raw)
+
+	statsRaw, err := client.Info(ctx, "stats")
+	if err != nil {
+		return nil, fmt.Errorf("info stats: %w", err)
+	}
+	stats := ParseInfo(statsRaw)
+
+	findings = append(findings, s.auditRDB(cfg, persistence, !rdbDisabled)...)
+
+	if !aofDisabled {
+		aofFindings, err := s.auditAOF(ctx, client, cfg, persistence, stats)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, aofFindings...)
+	}
+
+	if f, ok := s.auditFork(cfg, stats); ok {
+		findings = append(findings, f)
+	}
+
+	return findings, nil
+}
+
+func (s *PersistenceScanner) auditRDB(cfg AuditConfig, persistence map[string]string, rdbEnabled bool) []Finding {
+	var findings []Finding
+
+	if persistence["rdb_last_bgsave_status"] == "err" {
+		findings = append(findings, Finding{
+			ID:           FindingRDBSaveFailing,
+			Severity:     SeverityHigh,
+			ResourceType: "Persistence",
+			ResourceID:   cfg.Addr,
+			Message:      "last RDB background save failed; writes may be refused while stop-writes-on-bgsave-error is on",
+			Metadata: map[string]any{
+				"rdb_last_bgsave_status":      "err",
+				"rdb_changes_since_last_save": infoInt(persistence, "rdb_changes_since_last_save"),
+				"recommendation":              "check the server log for the fork or disk error and free disk space or memory",
+			},
+		})
+	}
+
+	lastSave := infoInt(persistence, "rdb_last_save_time")
+	changes := infoInt(persistence, "rdb_changes_since_last_save")
+	if rdbEnabled && lastSave > 0 && changes > 0 {
+		age := time.Since(time.Unix(lastSave, 0))
+		if age >= staleSaveAge {
+			severity := SeverityMedium
+			if age >= 2*staleSaveAge {
+				severity = SeverityHigh
+			}
+			days := int(age / (24 * time.Hour))
+			findings = append(findings, Finding{
+				ID:           FindingStaleSnapshot,
+				Severity:     severity,
+				ResourceType: "Persistence",
+				ResourceID:   cfg.Addr,
+				Message:      fmt.Sprintf("last successful RDB save was %d days ago with %d unsaved changes", days, changes),
+				Metadata: map[string]any{
+					"rdb_last_save_time":          lastSave,
+					"days_since_save":             days,
+					"rdb_changes_since_last_save": changes,
+				},
+			})
+		}
+	}
+
+	return findings
+}
+
+func (s *PersistenceScanner) auditAOF(ctx context.Context, client RedisClient, cfg AuditConfig, persistence, stats map[string]string) ([]Finding, error) {
+	var findings []Finding
+
+	if persistence["aof_last_write_status"] == "err" || persistence["aof_last_bgrewrite_status"] == "err" {
+		findings = append(findings, Finding{
+			ID:           FindingAOFWriteFailing,
+			Severity:     SeverityHigh,
+			ResourceType: "Persistence",
+			ResourceID:   cfg.Addr,
+			Message:      "AOF write or rewrite failed; acknowledged writes may not be durable",
+			Metadata: map[string]any{
+				"aof_last_write_status":     persistence["aof_last_write_status"],
+				"aof_last_bgrewrite_status": persistence["aof_last_bgrewrite_status"],
+				"recommendation":            "check the server log for the disk error and free disk space",
+			},
+		})
+	}
+
+	rewriteSeconds := infoInt(persistence, "aof_current_rewrite_time_sec")
+	if persistence["aof_rewrite_in_progress"] == "1" && rewriteSeconds > aofRewriteStuckSeconds {
+		findings = append(findings, Finding{
+			ID:           FindingAOFRewriteStuck,
+			Severity:     SeverityMedium,
+			ResourceType: "Persistence",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("AOF rewrite has been running for %ds", rewriteSeconds),
+			Metadata: map[string]any{
+				"aof_current_rewrite_time_sec": rewriteSeconds,
+				"aof_last_rewrite_time_sec":    infoInt(persistence, "aof_last_rewrite_time_sec"),
+			},
+		})
+	}
+
+	if !cfg.supports(CapConfig) {
+		cfg.skip(s.Name(), string(FindingAOFFsyncAlways), CapConfig)
+		return findings, nil
+	}
+	fsync, err := client.ConfigGet(ctx, "appendfsync")
+	if err != nil {
+		return nil, fmt.Errorf("config get appendfsync: %w", err)
+	}
+	ops := infoInt(stats, "instantaneous_ops_per_sec")
+	if fsync["appendfsync"] == "always" && ops >= fsyncAlwaysBusyOps {
+		findings = append(findings, Finding{
+			ID:           FindingAOFFsyncAlways,
+			Severity:     SeverityMedium,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("appendfsync always at %d ops/sec; every write waits for a disk fsync", ops),
+			Metadata: map[string]any{
+				"appendfsync":               "always",
+				"instantaneous_ops_per_sec": ops,
+				"recommendation":            "use appendfsync everysec unless every write must survive a power loss",
+			},
+		})
+	}
+
 	return findings, nil
 }
+
+func (s *PersistenceScanner) auditFork(cfg AuditConfig, stats map[string]string) (Finding, bool) {
+	forkUsec := infoInt(stats, "latest_fork_usec")
+	if forkUsec < slowForkUsec {
+		return Finding{}, false
+	}
+	severity := SeverityMedium
+	if forkUsec >= blockingForkUsec {
+		severity = SeverityHigh
+	}
+	return Finding{
+		ID:           FindingSlowFork,
+		Severity:     severity,
+		ResourceType: "Persistence",
+		ResourceID:   cfg.Addr,
+		Message:      fmt.Sprintf("last fork took %dms; the server does not serve clients while forking", forkUsec/1000),
+		Metadata: map[string]any{
+			"latest_fork_usec": forkUsec,
+			"recommendation":   "reduce dataset size per instance, disable transparent huge pages, or persist from a replica",
+		},
+	}, true
+}
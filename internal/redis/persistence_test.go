@@ -2,7 +2,10 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 )
 
 func TestPersistenceScanner_Name(t *testing.T) {
@@ -77,3 +80,150 @@ func TestPersistenceScanner_BothEnabled(t *testing.T) {
 		t.Errorf("expected 0 findings (both enabled), got %d", len(findings))
 	}
 }
+
+func TestPersistenceScanner_SaveAndWriteFailures(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": "3600 1"}
+	mock.configValues["appendonly"] = map[string]string{"appendonly": "yes"}
+	mock.infoResponses["persistence"] = "# Persistence\r\nrdb_last_bgsave_status:err\r\naof_last_write_status:err\r\naof_last_bgrewrite_status:ok\r\n"
+
+	s := &PersistenceScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	for _, id := range []FindingID{FindingRDBSaveFailing, FindingAOFWriteFailing} {
+		got := byID[id]
+		if len(got) != 1 {
+			t.Fatalf("expected 1 %s finding, got %+v", id, findings)
+		}
+		if got[0].Severity != SeverityHigh {
+			t.Errorf("%s: expected severity high, got %q", id, got[0].Severity)
+		}
+	}
+}
+
+func TestPersistenceScanner_StaleSnapshot(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": "3600 1"}
+	mock.configValues["appendonly"] = map[string]string{"appendonly": "no"}
+	lastSave := time.Now().Add(-3 * 24 * time.Hour).Unix()
+	mock.infoResponses["persistence"] = fmt.Sprintf("rdb_last_bgsave_status:ok\r\nrdb_last_save_time:%d\r\nrdb_changes_since_last_save:500\r\n", lastSave)
+
+	s := &PersistenceScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 || findings[0].ID != FindingStaleSnapshot {
+		t.Fatalf("expected 1 STALE_SNAPSHOT finding, got %+v", findings)
+	}
+	if findings[0].Severity != SeverityHigh {
+		t.Errorf("expected severity high, got %q", findings[0].Severity)
+	}
+	if findings[0].Metadata["days_since_save"] != 3 {
+		t.Errorf("expected 3 days since save, got %v", findings[0].Metadata["days_since_save"])
+	}
+}
+
+func TestPersistenceScanner_RecentSaveNotStale(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": "3600 1"}
+	mock.configValues["appendonly"] = map[string]string{"appendonly": "no"}
+	mock.infoResponses["persistence"] = fmt.Sprintf("rdb_last_save_time:%d\r\nrdb_changes_since_last_save:500\r\n", time.Now().Add(-time.Hour).Unix())
+
+	s := &PersistenceScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %+v", findings)
+	}
+}
+
+func TestPersistenceScanner_AOFRewriteStuckAndFsyncAlways(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": ""}
+	mock.configValues["appendonly"] = map[string]string{"appendonly": "yes"}
+	mock.configValues["appendfsync"] = map[string]string{"appendfsync": "always"}
+	mock.infoResponses["persistence"] = "aof_rewrite_in_progress:1\r\naof_current_rewrite_time_sec:7200\r\n"
+	mock.infoResponses["stats"] = "instantaneous_ops_per_sec:5000\r\n"
+
+	s := &PersistenceScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	if _, ok := byID[FindingAOFRewriteStuck]; !ok {
+		t.Errorf("expected AOF_REWRITE_STUCK finding, got %+v", findings)
+	}
+	if _, ok := byID[FindingAOFFsyncAlways]; !ok {
+		t.Errorf("expected AOF_FSYNC_ALWAYS finding, got %+v", findings)
+	}
+}
+
+func TestPersistenceScanner_FsyncAlwaysIdle(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": ""}
+	mock.configValues["appendonly"] = map[string]string{"appendonly": "yes"}
+	mock.configValues["appendfsync"] = map[string]string{"appendfsync": "always"}
+	mock.infoResponses["stats"] = "instantaneous_ops_per_sec:12\r\n"
+
+	s := &PersistenceScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings on an idle instance, got %+v", findings)
+	}
+}
+
+func TestPersistenceScanner_SlowFork(t *testing.T) {
+	tests := []struct {
+		forkUsec string
+		want     Severity
+	}{
+		{"100000", ""},
+		{"800000", SeverityMedium},
+		{"3000000", SeverityHigh},
+	}
+	for _, tt := range tests {
+		mock := newMockClient()
+		mock.configValues["save"] = map[string]string{"save": "3600 1"}
+		mock.configValues["appendonly"] = map[string]string{"appendonly": "no"}
+		mock.infoResponses["stats"] = "latest_fork_usec:" + tt.forkUsec + "\r\n"
+
+		s := &PersistenceScanner{}
+		findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tt.want == "" {
+			if len(findings) != 0 {
+				t.Errorf("fork %s: expected 0 findings, got %+v", tt.forkUsec, findings)
+			}
+			continue
+		}
+		if len(findings) != 1 || findings[0].ID != FindingSlowFork {
+			t.Fatalf("fork %s: expected 1 SLOW_FORK finding, got %+v", tt.forkUsec, findings)
+		}
+		if findings[0].Severity != tt.want {
+			t.Errorf("fork %s: expected severity %q, got %q", tt.forkUsec, tt.want, findings[0].Severity)
+		}
+	}
+}
+
+func TestPersistenceScanner_InfoError(t *testing.T) {
+	mock := newMockClient()
+	mock.configValues["save"] = map[string]string{"save": "3600 1"}
+	mock.infoErr = errors.New("connection refused")
+
+	s := &PersistenceScanner{}
+	if _, err := s.Audit(context.Background(), mock, AuditConfig{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,259 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Capability is a server feature some auditors depend on.
+type Capability string
+
+const (
+	// CapConfig is CONFIG GET, which managed services often rename or block.
+	CapConfig Capability = "config"
+	// CapObjectIdletime is OBJECT IDLETIME, unavailable under LFU policies.
+	CapObjectIdletime Capability = "object_idletime"
+	// CapObjectFreq is OBJECT FREQ, available only under LFU policies.
+	CapObjectFreq Capability = "object_freq"
+	// CapMemoryStats is MEMORY STATS (Redis 4.0+).
+	CapMemoryStats Capability = "memory_stats"
+	// CapMallocStats is MEMORY MALLOC-STATS on a jemalloc build.
+	CapMallocStats Capability = "malloc_stats"
+	// CapLatency is the LATENCY command family (Redis 2.8.13+).
+	CapLatency Capability = "latency"
+	// CapACL is ACL LIST and ACL LOG (Redis 6.0+).
+	CapACL Capability = "acl"
+	// CapModules is MODULE LIST (Redis 4.0+).
+	CapModules Capability = "modules"
+)
+
+// Managed services detected from INFO server.
+const (
+	ManagedElastiCache = "elasticache"
+	ManagedMemoryDB    = "memorydb"
+)
+
+// Profile describes what the audited server supports. A nil Profile
+// supports everything.
+type Profile struct {
+	Server ServerVersion
+	// Managed names the managed service hosting the server, if any.
+	Managed string
+	// Policy is the maxmemory-policy, from CONFIG or INFO memory.
+	Policy string
+	// Unavailable maps missing capabilities to the reason.
+	Unavailable map[Capability]string
+}
+
+// Supports reports whether the server has capability c.
+func (p *Profile) Supports(c Capability) bool {
+	if p == nil {
+		return true
+	}
+	_, missing := p.Unavailable[c]
+	return !missing
+}
+
+// Reason explains why capability c is unavailable.
+func (p *Profile) Reason(c Capability) string {
+	if p == nil {
+		return ""
+	}
+	return p.Unavailable[c]
+}
+
+// require returns the reason for the first missing capability of caps.
+func (p *Profile) require(caps ...Capability) (string, bool) {
+	for _, c := range caps {
+		if !p.Supports(c) {
+			return p.Reason(c), false
+		}
+	}
+	return "", true
+}
+
+func (p *Profile) unavailable(c Capability, reason string) {
+	if _, ok := p.Unavailable[c]; !ok {
+		p.Unavailable[c] = reason
+	}
+}
+
+// minVersions are the Redis versions that introduced version-gated
+// capabilities.
+var minVersions = map[Capability]version{
+	CapMemoryStats: {4, 0, 0},
+	CapObjectFreq:  {4, 0, 0},
+	CapLatency:     {2, 8, 13},
+	CapACL:         {6, 0, 0},
+	CapModules:     {4, 0, 0},
+}
+
+// flavorGaps lists capabilities a flavor does not implement.
+var flavorGaps = map[string][]Capability{
+	FlavorDragonfly: {CapObjectFreq, CapObjectIdletime, CapLatency, CapMallocStats, CapMemoryStats, CapModules},
+}
+
+// DetectProfile identifies the server and probes the capabilities auditors
+// depend on. It reads INFO server and INFO memory and tries CONFIG GET.
+func DetectProfile(ctx context.Context, client RedisClient) (*Profile, error) {
+	serverRaw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	server := ParseInfo(serverRaw)
+	memoryRaw, err := client.Info(ctx, "memory")
+	if err != nil {
+		return nil, fmt.Errorf("info memory: %w", err)
+	}
+	memory := ParseInfo(memoryRaw)
+
+	p := &Profile{
+		Server:      DetectServer(server),
+		Policy:      memory["maxmemory_policy"],
+		Unavailable: make(map[Capability]string),
+	}
+	switch os := server["os"]; {
+	case strings.Contains(os, "ElastiCache"):
+		p.Managed = ManagedElastiCache
+	case strings.Contains(os, "MemoryDB"):
+		p.Managed = ManagedMemoryDB
+	}
+
+	if conf, err := client.ConfigGet(ctx, "maxmemory-policy"); err != nil {
+		p.unavailable(CapConfig, fmt.Sprintf("CONFIG GET is not available: %v", err))
+	} else if policy := conf["maxmemory-policy"]; policy != "" {
+		p.Policy = policy
+	}
+
+	for _, c := range flavorGaps[p.Server.Flavor] {
+		p.unavailable(c, fmt.Sprintf("not implemented by %s", p.Server.Flavor))
+	}
+	if v, ok := parseVersion(p.Server.RedisVersion); ok {
+		for c, min := range minVersions {
+			if v.less(min) {
+				p.unavailable(c, fmt.Sprintf("requires Redis %d.%d or later (server reports %s)", min[0], min[1], p.Server.RedisVersion))
+			}
+		}
+	}
+	if allocator := memory["mem_allocator"]; allocator != "" && !strings.HasPrefix(allocator, "jemalloc") {
+		p.unavailable(CapMallocStats, fmt.Sprintf("server uses %s, not jemalloc", allocator))
+	}
+	if strings.Contains(p.Policy, "lfu") {
+		p.unavailable(CapObjectIdletime, fmt.Sprintf("OBJECT IDLETIME is not available under maxmemory-policy %s", p.Policy))
+	} else {
+		p.unavailable(CapObjectFreq, "OBJECT FREQ requires an LFU maxmemory-policy")
+	}
+	return p, nil
+}
+
+// summary returns the profile for the inventory.
+func (p *Profile) summary() map[string]any {
+	unavailable := make([]string, 0, len(p.Unavailable))
+	for c := range p.Unavailable {
+		unavailable = append(unavailable, string(c))
+	}
+	sort.Strings(unavailable)
+	return map[string]any{
+		"flavor":        p.Server.Flavor,
+		"version":       p.Server.Version,
+		"redis_version": p.Server.RedisVersion,
+		"managed":       p.Managed,
+		"policy":        p.Policy,
+		"unavailable":   unavailable,
+	}
+}
+
+// SkippedCheck is an auditor, or one check within it, that could not run
+// against the server.
+type SkippedCheck struct {
+	Auditor string `json:"auditor"`
+	Check   string `json:"check,omitempty"`
+	Reason  string `json:"reason"`
+}
+
+// profileChecker is implemented by auditors that cannot run on every
+// server. Applicable returns the reason when the auditor does not apply.
+type profileChecker interface {
+	Applicable(p *Profile) (string, bool)
+}
+
+// skipList collects checks auditors skipped; it is nil when an auditor runs
+// outside MultiAuditor.
+type skipList struct {
+	mu     sync.Mutex
+	checks []SkippedCheck
+}
+
+func (l *skipList) add(c SkippedCheck) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.checks = append(l.checks, c)
+}
+
+// supports reports whether the audited server has capability c.
+func (c AuditConfig) supports(capability Capability) bool {
+	return c.Profile.Supports(capability)
+}
+
+// skip records that check of auditor did not run for lack of capability.
+func (c AuditConfig) skip(auditor, check string, capability Capability) {
+	c.skipped.add(SkippedCheck{Auditor: auditor, Check: check, Reason: c.Profile.Reason(capability)})
+}
+
+// maxmemoryPolicy reads maxmemory-policy from CONFIG, or from INFO memory
+// when CONFIG is unavailable.
+func maxmemoryPolicy(ctx context.Context, client RedisClient, cfg AuditConfig) (string, error) {
+	if cfg.supports(CapConfig) {
+		conf, err := client.ConfigGet(ctx, "maxmemory-policy")
+		if err != nil {
+			return "", fmt.Errorf("config get maxmemory-policy: %w", err)
+		}
+		return conf["maxmemory-policy"], nil
+	}
+	raw, err := client.Info(ctx, "memory")
+	if err != nil {
+		return "", fmt.Errorf("info memory: %w", err)
+	}
+	return ParseInfo(raw)["maxmemory_policy"], nil
+}
+
+// rdbInferred stands in for the save setting when RDB use is inferred from
+// INFO persistence.
+const rdbInferred = "(inferred from INFO persistence)"
+
+// persistenceConfig returns the save and appendonly settings. Without
+// CONFIG they are inferred from INFO persistence: AOF from aof_enabled, and
+// RDB from a completed background save.
+func persistenceConfig(ctx context.Context, client RedisClient, cfg AuditConfig) (save, appendonly string, err error) {
+	if cfg.supports(CapConfig) {
+		saveConfig, err := client.ConfigGet(ctx, "save")
+		if err != nil {
+			return "", "", fmt.Errorf("config get save: %w", err)
+		}
+		aofConfig, err := client.ConfigGet(ctx, "appendonly")
+		if err != nil {
+			return "", "", fmt.Errorf("config get appendonly: %w", err)
+		}
+		return saveConfig["save"], aofConfig["appendonly"], nil
+	}
+
+	raw, err := client.Info(ctx, "persistence")
+	if err != nil {
+		return "", "", fmt.Errorf("info persistence: %w", err)
+	}
+	info := ParseInfo(raw)
+	appendonly = "no"
+	if info["aof_enabled"] == "1" {
+		appendonly = "yes"
+	}
+	if infoInt(info, "rdb_saves") > 0 || (info["rdb_last_bgsave_time_sec"] != "" && info["rdb_last_bgsave_time_sec"] != "-1") {
+		save = rdbInferred
+	}
+	return save, appendonly, nil
+}
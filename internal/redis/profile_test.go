@@ -0,0 +1,175 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestDetectProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		server      string
+		memory      string
+		configErr   error
+		managed     string
+		unavailable []Capability
+		available   []Capability
+	}{
+		{
+			name:        "redis 7 with lru",
+			server:      "redis_version:7.2.4\r\n",
+			memory:      "mem_allocator:jemalloc-5.3.0\r\nmaxmemory_policy:allkeys-lru\r\n",
+			unavailable: []Capability{CapObjectFreq},
+			available:   []Capability{CapConfig, CapObjectIdletime, CapMemoryStats, CapMallocStats, CapLatency, CapACL},
+		},
+		{
+			name:        "elasticache blocks config",
+			server:      "redis_version:7.1.0\r\nos:Amazon ElastiCache\r\n",
+			memory:      "mem_allocator:jemalloc-5.2.1\r\nmaxmemory_policy:allkeys-lfu\r\n",
+			configErr:   errors.New("ERR unknown command 'CONFIG'"),
+			managed:     ManagedElastiCache,
+			unavailable: []Capability{CapConfig, CapObjectIdletime},
+			available:   []Capability{CapObjectFreq, CapACL},
+		},
+		{
+			name:        "redis 5",
+			server:      "redis_version:5.0.7\r\n",
+			memory:      "mem_allocator:libc\r\nmaxmemory_policy:noeviction\r\n",
+			unavailable: []Capability{CapACL, CapMallocStats, CapObjectFreq},
+			available:   []Capability{CapMemoryStats, CapLatency, CapObjectIdletime},
+		},
+		{
+			name:        "dragonfly",
+			server:      "redis_version:7.4.0\r\ndragonfly_version:df-v1.21.2\r\n",
+			memory:      "maxmemory_policy:noeviction\r\n",
+			unavailable: []Capability{CapObjectIdletime, CapObjectFreq, CapLatency, CapMemoryStats},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.infoResponses["server"] = tt.server
+			mock.infoResponses["memory"] = tt.memory
+			mock.configErr = tt.configErr
+
+			p, err := DetectProfile(context.Background(), mock)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p.Managed != tt.managed {
+				t.Errorf("expected managed %q, got %q", tt.managed, p.Managed)
+			}
+			for _, c := range tt.unavailable {
+				if p.Supports(c) {
+					t.Errorf("expected %s to be unavailable", c)
+				} else if p.Reason(c) == "" {
+					t.Errorf("expected a reason for %s", c)
+				}
+			}
+			for _, c := range tt.available {
+				if !p.Supports(c) {
+					t.Errorf("expected %s to be available, got %q", c, p.Reason(c))
+				}
+			}
+		})
+	}
+}
+
+func TestProfile_NilSupportsAll(t *testing.T) {
+	var p *Profile
+	if !p.Supports(CapConfig) {
+		t.Error("expected a nil profile to support every capability")
+	}
+}
+
+func TestMultiAuditorSkipsInapplicable(t *testing.T) {
+	mock := newMockClient()
+	mock.configErr = errors.New("ERR unknown command 'CONFIG'")
+	mock.infoResponses["persistence"] = "aof_enabled:0\r\nrdb_last_bgsave_time_sec:-1\r\n"
+	profile := &Profile{Unavailable: map[Capability]string{
+		CapConfig:      "CONFIG GET is not available",
+		CapMemoryStats: "requires Redis 4.0 or later",
+	}}
+
+	multi := NewMultiAuditor([]Auditor{&SecurityScanner{}, &MemoryOverheadScanner{}, &PersistenceScanner{}}, 2)
+	result, err := multi.AuditAll(context.Background(), mock, AuditConfig{Profile: profile})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors, got %v", result.Errors)
+	}
+	if len(result.Skipped) == 0 || result.Skipped[0].Auditor != "memory_overhead" || result.Skipped[0].Check != "" {
+		t.Errorf("expected memory_overhead to be skipped, got %+v", result.Skipped)
+	}
+	for _, sk := range result.Skipped[1:] {
+		if sk.Auditor == "security" && sk.Check == "" {
+			t.Errorf("expected security to run with only its CONFIG checks skipped, got %+v", sk)
+		}
+	}
+	if len(findingsByID(result.Findings)[FindingNoPersistence]) != 1 {
+		t.Errorf("expected persistence fallback to report NO_PERSISTENCE, got %+v", result.Findings)
+	}
+	if _, ok := result.Inventory["profile"]; !ok {
+		t.Error("expected profile in inventory")
+	}
+}
+
+func TestFallbacksWithoutConfig(t *testing.T) {
+	noConfig := AuditConfig{Profile: &Profile{Unavailable: map[Capability]string{CapConfig: "blocked"}}}
+
+	t.Run("eviction policy from INFO memory", func(t *testing.T) {
+		mock := newMockClient()
+		mock.configErr = errors.New("ERR unknown command 'CONFIG'")
+		mock.infoResponses["memory"] = "used_memory:900000\r\nmaxmemory:1000000\r\nmaxmemory_policy:noeviction\r\n"
+		mock.infoResponses["persistence"] = "aof_enabled:1\r\n"
+
+		findings, err := (&EvictionScanner{}).Audit(context.Background(), mock, noConfig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findingsByID(findings)[FindingEvictionRisk]) != 1 {
+			t.Errorf("expected EVICTION_RISK from INFO policy, got %+v", findings)
+		}
+	})
+
+	t.Run("persistence from INFO persistence", func(t *testing.T) {
+		mock := newMockClient()
+		mock.configErr = errors.New("ERR unknown command 'CONFIG'")
+		mock.infoResponses["persistence"] = "aof_enabled:0\r\nrdb_saves:3\r\n"
+
+		findings, err := (&PersistenceScanner{}).Audit(context.Background(), mock, noConfig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(findingsByID(findings)[FindingNoPersistence]) != 0 {
+			t.Errorf("expected completed RDB saves to count as persistence, got %+v", findings)
+		}
+	})
+
+	t.Run("checks without fallback are skipped", func(t *testing.T) {
+		mock := newMockClient()
+		mock.configErr = errors.New("ERR unknown command 'CONFIG'")
+		mock.infoResponses["replication"] = "role:slave\r\nmaster_link_status:up\r\n"
+		cfg := noConfig
+		cfg.skipped = &skipList{}
+		for _, a := range []Auditor{&SlowLogScanner{}, &LatencyScanner{}, &ConnectionScanner{}, &ReplicationScanner{}} {
+			if _, err := a.Audit(context.Background(), mock, cfg); err != nil {
+				t.Errorf("%s: unexpected error: %v", a.Name(), err)
+			}
+		}
+		skipped := make(map[string]bool)
+		for _, c := range cfg.skipped.checks {
+			skipped[c.Auditor] = true
+			if c.Check == "" || c.Reason != "blocked" {
+				t.Errorf("expected a named check with the profile reason, got %+v", c)
+			}
+		}
+		for _, name := range []string{"slowlog", "latency", "connections", "replication"} {
+			if !skipped[name] {
+				t.Errorf("expected %s to record a skipped check", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,211 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ArgRedaction controls how command arguments appear in reports.
+type ArgRedaction string
+
+const (
+	// RedactKeys keeps the command and key names and replaces every other
+	// argument with "?". It is the default.
+	RedactKeys ArgRedaction = "keys"
+	// RedactLengths keeps the command and key names and replaces every other
+	// argument with its length.
+	RedactLengths ArgRedaction = "lengths"
+	// RedactNone keeps arguments as sent. Secrets are still masked.
+	RedactNone ArgRedaction = "full"
+)
+
+const redactedSecret = "(redacted)"
+
+// ParseArgRedaction validates a redaction mode; an empty string is the
+// default, RedactKeys.
+func ParseArgRedaction(s string) (ArgRedaction, error) {
+	switch mode := ArgRedaction(strings.ToLower(s)); mode {
+	case "":
+		return RedactKeys, nil
+	case RedactKeys, RedactLengths, RedactNone:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("unknown argument redaction %q (want keys, lengths, or full)", s)
+	}
+}
+
+// keySpec locates key arguments like the key specs of COMMAND INFO: keys
+// run from first to last (negative counts from the end) every step.
+type keySpec struct {
+	first, last, step int
+}
+
+// commandKeySpecs covers commands whose keys are not just the first
+// argument. Commands missing from the table take one key at position 1.
+var commandKeySpecs = map[string]keySpec{
+	"DEL": {1, -1, 1}, "UNLINK": {1, -1, 1}, "EXISTS": {1, -1, 1}, "TOUCH": {1, -1, 1},
+	"MGET": {1, -1, 1}, "WATCH": {1, -1, 1}, "PFCOUNT": {1, -1, 1}, "PFMERGE": {1, -1, 1},
+	"SDIFF": {1, -1, 1}, "SINTER": {1, -1, 1}, "SUNION": {1, -1, 1},
+	"SDIFFSTORE": {1, -1, 1}, "SINTERSTORE": {1, -1, 1}, "SUNIONSTORE": {1, -1, 1},
+	"MSET": {1, -1, 2}, "MSETNX": {1, -1, 2},
+	"BLPOP": {1, -2, 1}, "BRPOP": {1, -2, 1}, "BZPOPMIN": {1, -2, 1}, "BZPOPMAX": {1, -2, 1},
+	"RENAME": {1, 2, 1}, "RENAMENX": {1, 2, 1}, "RPOPLPUSH": {1, 2, 1}, "BRPOPLPUSH": {1, 2, 1},
+	"LMOVE": {1, 2, 1}, "BLMOVE": {1, 2, 1}, "SMOVE": {1, 2, 1}, "COPY": {1, 2, 1},
+	"BITOP": {2, -1, 1},
+}
+
+// commandKeyIndexes returns the positions of key arguments in args.
+func commandKeyIndexes(args []string) []int {
+	name := strings.ToUpper(args[0])
+	switch {
+	case keylessCommands[name]:
+		return nil
+	case name == "OBJECT" || name == "MEMORY":
+		return keyRange(args, keySpec{2, 2, 1})
+	case containerCommands[name]:
+		return nil
+	case name == "EVAL" || name == "EVALSHA" || name == "EVAL_RO" || name == "EVALSHA_RO" ||
+		name == "FCALL" || name == "FCALL_RO":
+		return numKeysRange(args, 2)
+	case name == "ZUNIONSTORE" || name == "ZINTERSTORE" || name == "ZDIFFSTORE":
+		return append(keyRange(args, keySpec{1, 1, 1}), numKeysRange(args, 2)...)
+	case name == "ZUNION" || name == "ZINTER" || name == "ZDIFF" || name == "SINTERCARD" ||
+		name == "LMPOP" || name == "ZMPOP":
+		return numKeysRange(args, 1)
+	case name == "MIGRATE":
+		if len(args) > 3 && args[3] != "" {
+			return []int{3}
+		}
+		for i := 6; i < len(args); i++ {
+			if strings.EqualFold(args[i], "KEYS") {
+				return keyRange(args, keySpec{i + 1, -1, 1})
+			}
+		}
+		return nil
+	case name == "XREAD" || name == "XREADGROUP":
+		for i := 1; i < len(args); i++ {
+			if strings.EqualFold(args[i], "STREAMS") {
+				n := (len(args) - i - 1) / 2
+				return keyRange(args, keySpec{i + 1, i + n, 1})
+			}
+		}
+		return nil
+	}
+	if spec, ok := commandKeySpecs[name]; ok {
+		return keyRange(args, spec)
+	}
+	return keyRange(args, keySpec{1, 1, 1})
+}
+
+func keyRange(args []string, spec keySpec) []int {
+	last := spec.last
+	if last < 0 {
+		last = len(args) + last
+	}
+	var idx []int
+	for i := spec.first; i <= last && i < len(args); i += spec.step {
+		idx = append(idx, i)
+	}
+	return idx
+}
+
+// numKeysRange returns the keys following a numkeys argument at position n.
+func numKeysRange(args []string, n int) []int {
+	if len(args) <= n {
+		return nil
+	}
+	count, err := strconv.Atoi(args[n])
+	if err != nil || count <= 0 {
+		return nil
+	}
+	return keyRange(args, keySpec{n + 1, n + count, 1})
+}
+
+// secretConfigParams are CONFIG SET parameters whose values are credentials.
+var secretConfigParams = map[string]bool{
+	"requirepass":              true,
+	"masterauth":               true,
+	"tls-key-file-pass":        true,
+	"tls-client-key-file-pass": true,
+}
+
+// secretArgIndexes returns the positions of credentials in args: AUTH
+// passwords, HELLO ... AUTH and MIGRATE ... AUTH/AUTH2 passwords, CONFIG SET
+// password parameters, and ACL SETUSER password rules.
+func secretArgIndexes(args []string) map[int]bool {
+	secrets := make(map[int]bool)
+	name := strings.ToUpper(args[0])
+	switch name {
+	case "AUTH":
+		if len(args) > 1 {
+			secrets[len(args)-1] = true
+		}
+	case "HELLO":
+		for i := 1; i < len(args); i++ {
+			if strings.EqualFold(args[i], "AUTH") && i+2 < len(args) {
+				secrets[i+2] = true
+			}
+		}
+	case "MIGRATE":
+		for i := 6; i < len(args); i++ {
+			switch {
+			case strings.EqualFold(args[i], "AUTH") && i+1 < len(args):
+				secrets[i+1] = true
+			case strings.EqualFold(args[i], "AUTH2") && i+2 < len(args):
+				secrets[i+2] = true
+			}
+		}
+	case "CONFIG":
+		if len(args) > 1 && strings.EqualFold(args[1], "SET") {
+			for i := 2; i+1 < len(args); i += 2 {
+				if secretConfigParams[strings.ToLower(args[i])] {
+					secrets[i+1] = true
+				}
+			}
+		}
+	case "ACL":
+		if len(args) > 1 && strings.EqualFold(args[1], "SETUSER") {
+			for i := 3; i < len(args); i++ {
+				if strings.HasPrefix(args[i], ">") || strings.HasPrefix(args[i], "<") ||
+					strings.HasPrefix(args[i], "#") || strings.HasPrefix(args[i], "!") {
+					secrets[i] = true
+				}
+			}
+		}
+	}
+	return secrets
+}
+
+// RedactArgs renders a command's arguments for a report. The command name,
+// the subcommand of container commands, and key names are kept; other
+// arguments are kept, replaced by their length, or replaced by "?" depending
+// on mode. Credentials are masked in every mode.
+func RedactArgs(args []string, mode ArgRedaction) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	keep := map[int]bool{0: true}
+	if containerCommands[strings.ToUpper(args[0])] && len(args) > 1 {
+		keep[1] = true
+	}
+	for _, i := range commandKeyIndexes(args) {
+		keep[i] = true
+	}
+	secrets := secretArgIndexes(args)
+
+	out := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case secrets[i]:
+			out[i] = redactedSecret
+		case keep[i] || mode == RedactNone:
+			out[i] = arg
+		case mode == RedactLengths:
+			out[i] = fmt.Sprintf("<%dB>", len(arg))
+		default:
+			out[i] = "?"
+		}
+	}
+	return out
+}
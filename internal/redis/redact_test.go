@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseArgRedaction(t *testing.T) {
+	for in, want := range map[string]ArgRedaction{"": RedactKeys, "keys": RedactKeys, "LENGTHS": RedactLengths, "full": RedactNone} {
+		got, err := ParseArgRedaction(in)
+		if err != nil || got != want {
+			t.Errorf("ParseArgRedaction(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseArgRedaction("none"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+}
+
+func TestRedactArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		mode ArgRedaction
+		want string
+	}{
+		{"key only", []string{"SET", "user:1:email", "bob@example.com", "EX", "60"}, RedactKeys, "SET user:1:email ? ? ?"},
+		{"lengths", []string{"SET", "user:1:email", "bob@example.com"}, RedactLengths, "SET user:1:email <15B>"},
+		{"full", []string{"SET", "user:1:email", "bob@example.com"}, RedactNone, "SET user:1:email bob@example.com"},
+		{"multi-key", []string{"MSET", "a", "1", "b", "2"}, RedactKeys, "MSET a ? b ?"},
+		{"eval keys", []string{"EVAL", "return 1", "2", "k1", "k2", "v"}, RedactKeys, "EVAL ? ? k1 k2 ?"},
+		{"xread streams", []string{"XREAD", "COUNT", "10", "STREAMS", "s1", "s2", "0", "0"}, RedactKeys, "XREAD ? ? ? s1 s2 ? ?"},
+		{"container", []string{"CONFIG", "GET", "maxmemory"}, RedactNone, "CONFIG GET maxmemory"},
+		{"auth", []string{"AUTH", "hunter2"}, RedactNone, "AUTH (redacted)"},
+		{"auth with user", []string{"AUTH", "app", "hunter2"}, RedactNone, "AUTH app (redacted)"},
+		{"hello auth", []string{"HELLO", "3", "AUTH", "app", "hunter2", "SETNAME", "worker"}, RedactNone, "HELLO 3 AUTH app (redacted) SETNAME worker"},
+		{"migrate auth", []string{"MIGRATE", "10.0.0.2", "6379", "k", "0", "5000", "AUTH", "hunter2"}, RedactNone, "MIGRATE 10.0.0.2 6379 k 0 5000 AUTH (redacted)"},
+		{"migrate auth2 keys", []string{"MIGRATE", "h", "6379", "", "0", "5000", "AUTH2", "app", "hunter2", "KEYS", "a", "b"}, RedactNone, "MIGRATE h 6379  0 5000 AUTH2 app (redacted) KEYS a b"},
+		{"config set", []string{"CONFIG", "SET", "maxmemory", "1gb", "requirepass", "hunter2"}, RedactNone, "CONFIG SET maxmemory 1gb requirepass (redacted)"},
+		{"acl setuser", []string{"ACL", "SETUSER", "app", "on", ">hunter2", "~*"}, RedactNone, "ACL SETUSER app on (redacted) ~*"},
+		{"secrets masked in lengths", []string{"AUTH", "hunter2"}, RedactLengths, "AUTH (redacted)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Join(RedactArgs(tt.args, tt.mode), " ")
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// replicaLagBytesThreshold flags replicas this far behind the primary.
+	replicaLagBytesThreshold = 1024 * 1024 // 1 MB
+	// backlogWindowSeconds is how long a replica should be able to stay
+	// disconnected and still resume with a partial resync.
+	backlogWindowSeconds = 60
+	// partialSyncMinPerHour is the average failure rate over uptime (one a
+	// day) that counts as current when there is no previous audit.
+	partialSyncMinPerHour = 1.0 / 24
+)
+
+// ReplicationScanner audits replication health from INFO replication and
+// the replication-related configuration.
+type ReplicationScanner struct{}
+
+func (s *ReplicationScanner) Name() string { return "replication" }
+
+func (s *ReplicationScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.Info(ctx, "replication")
+	if err != nil {
+		return nil, fmt.Errorf("info replication: %w", err)
+	}
+	repl := ParseInfo(raw)
+
+	statsRaw, err := client.Info(ctx, "stats")
+	if err != nil {
+		return nil, fmt.Errorf("info stats: %w", err)
+	}
+	stats := ParseInfo(statsRaw)
+
+	serverRaw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	server := ParseInfo(serverRaw)
+
+	var findings []Finding
+
+	switch repl["role"] {
+	case "slave":
+		replicaFindings, err := s.auditReplica(ctx, client, cfg, repl)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, replicaFindings...)
+	case "master":
+		primaryFindings, err := s.auditPrimary(ctx, client, cfg, repl, server)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, primaryFindings...)
+	}
+
+	partialErr := countersFor(cfg, stats, server).Rate("sync_partial_err")
+	if partialErr.Active(partialSyncMinPerHour) {
+		findings = append(findings, Finding{
+			ID:           FindingPartialSyncFailures,
+			Severity:     SeverityMedium,
+			ResourceType: "Replication",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("%d partial resync attempts failed and fell back to full resyncs %s", partialErr.Count, partialErr.Describe()),
+			Metadata: map[string]any{
+				"sync_partial_err": partialErr.Count,
+				"sync_partial_ok":  infoInt(stats, "sync_partial_ok"),
+				"sync_full":        infoInt(stats, "sync_full"),
+				"counter_window":   partialErr.Source,
+				"recommendation":   "increase repl-backlog-size so reconnecting replicas can resume from the backlog",
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+func (s *ReplicationScanner) auditReplica(ctx context.Context, client RedisClient, cfg AuditConfig, repl map[string]string) ([]Finding, error) {
+	var findings []Finding
+
+	if repl["master_link_status"] == "down" {
+		master := repl["master_host"] + ":" + repl["master_port"]
+		downSince := infoInt(repl, "master_link_down_since_seconds")
+		findings = append(findings, Finding{
+			ID:           FindingReplicaLinkDown,
+			Severity:     SeverityCritical,
+			ResourceType: "Replication",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("replica link to primary %s is down (for %ds)", master, downSince),
+			Metadata: map[string]any{
+				"master":                         master,
+				"master_link_down_since_seconds": downSince,
+				"master_sync_in_progress":        repl["master_sync_in_progress"],
+			},
+		})
+	}
+
+	readOnly := make(map[string]string)
+	if cfg.supports(CapConfig) {
+		var err error
+		readOnly, err = client.ConfigGet(ctx, "replica-read-only")
+		if err != nil {
+			return nil, fmt.Errorf("config get replica-read-only: %w", err)
+		}
+	} else {
+		cfg.skip(s.Name(), string(FindingReplicaWritable), CapConfig)
+	}
+	if readOnly["replica-read-only"] == "no" {
+		findings = append(findings, Finding{
+			ID:           FindingReplicaWritable,
+			Severity:     SeverityMedium,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      "replica accepts writes (replica-read-only no); writes diverge from the primary and are lost on resync",
+			Metadata: map[string]any{
+				"replica-read-only": "no",
+				"recommendation":    "set replica-read-only yes",
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+func (s *ReplicationScanner) auditPrimary(ctx context.Context, client RedisClient, cfg AuditConfig, repl, server map[string]string) ([]Finding, error) {
+	var findings []Finding
+
+	masterOffset := infoInt(repl, "master_repl_offset")
+	backlogSize := infoInt(repl, "repl_backlog_size")
+
+	for _, name := range replicaFields(repl) {
+		r := ParseInfoFields(repl[name])
+		offset, _ := strconv.ParseInt(r["offset"], 10, 64)
+		lagBytes := masterOffset - offset
+		if lagBytes < replicaLagBytesThreshold {
+			continue
+		}
+		severity := SeverityMedium
+		if backlogSize > 0 && lagBytes > backlogSize {
+			// A disconnect now would force a full resync.
+			severity = SeverityHigh
+		}
+		replica := r["ip"] + ":" + r["port"]
+		findings = append(findings, Finding{
+			ID:           FindingReplicaLag,
+			Severity:     severity,
+			ResourceType: "Replication",
+			ResourceID:   replica,
+			Message:      fmt.Sprintf("replica %s is %s behind the primary", replica, FormatBytes(lagBytes)),
+			Metadata: map[string]any{
+				"replica":           replica,
+				"state":             r["state"],
+				"lag_bytes":         lagBytes,
+				"lag_seconds":       r["lag"],
+				"repl_backlog_size": backlogSize,
+			},
+		})
+	}
+
+	connectedReplicas := infoInt(repl, "connected_slaves")
+
+	// The replication stream rate approximates the write rate. The offset
+	// survives restarts through RDB and PSYNC while uptime resets, so right
+	// after a restart the offset over uptime overstates it badly.
+	bytesPerSec, source := replStreamRate(cfg, repl, server)
+	if source == RateSinceRestart && countersFor(cfg, repl, server).RecentlyRestarted() {
+		cfg.skipped.add(SkippedCheck{
+			Auditor: s.Name(),
+			Check:   string(FindingReplBacklogTooSmall),
+			Reason:  "restarted within the last hour; the replication offset predates the restart, so the write rate is unknown without --state-file or --sample-window",
+		})
+		bytesPerSec = 0
+	}
+	if connectedReplicas > 0 && bytesPerSec > 0 && backlogSize > 0 {
+		needed := int64(bytesPerSec * backlogWindowSeconds)
+		if backlogSize < needed {
+			suggested := roundUpMB(needed * 2)
+			findings = append(findings, Finding{
+				ID:           FindingReplBacklogTooSmall,
+				Severity:     SeverityMedium,
+				ResourceType: "Config",
+				ResourceID:   cfg.Addr,
+				Message: fmt.Sprintf("repl-backlog-size %s covers less than %ds of writes at %s/s; replicas reconnecting later need a full resync",
+					FormatBytes(backlogSize), backlogWindowSeconds, FormatBytes(int64(bytesPerSec))),
+				Metadata: map[string]any{
+					"repl_backlog_size":        backlogSize,
+					"write_bytes_per_sec":      bytesPerSec,
+					"counter_window":           source,
+					"suggested_backlog_bytes":  suggested,
+					"suggested_backlog_config": fmt.Sprintf("repl-backlog-size %dmb", suggested/(1024*1024)),
+				},
+			})
+		}
+	}
+
+	if connectedReplicas > 0 && !cfg.supports(CapConfig) {
+		cfg.skip(s.Name(), string(FindingMinReplicasNotSet), CapConfig)
+	} else if connectedReplicas > 0 {
+		minReplicas, err := client.ConfigGet(ctx, "min-replicas-to-write")
+		if err != nil {
+			return nil, fmt.Errorf("config get min-replicas-to-write: %w", err)
+		}
+		if v, ok := minReplicas["min-replicas-to-write"]; ok && v == "0" {
+			findings = append(findings, Finding{
+				ID:           FindingMinReplicasNotSet,
+				Severity:     SeverityLow,
+				ResourceType: "Config",
+				ResourceID:   cfg.Addr,
+				Message:      fmt.Sprintf("primary with %d replica(s) accepts writes with no replica connected (min-replicas-to-write 0)", connectedReplicas),
+				Metadata: map[string]any{
+					"connected_slaves": connectedReplicas,
+					"recommendation":   "set min-replicas-to-write 1 and min-replicas-max-lag 10 on primaries that must not lose acknowledged writes",
+				},
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// replStreamRate returns the replication stream in bytes per second and the
+// window it covers: the sample window when there is one, else the offset's
+// increase since the last audit, else the offset averaged over uptime.
+func replStreamRate(cfg AuditConfig, repl, server map[string]string) (float64, string) {
+	if cfg.window != nil && cfg.window.Duration() > 0 {
+		return cfg.window.PerSecond("master_repl_offset"), "sample_window"
+	}
+	r := countersFor(cfg, repl, server).Rate("master_repl_offset")
+	if r.Window <= 0 {
+		return 0, r.Source
+	}
+	return float64(r.Count) / r.Window.Seconds(), r.Source
+}
+
+// replicaFields returns the slaveN field names in INFO replication, ordered
+// by N so slave10 follows slave9.
+func replicaFields(repl map[string]string) []string {
+	index := make(map[string]int)
+	var names []string
+	for k := range repl {
+		if n, ok := strings.CutPrefix(k, "slave"); ok {
+			if i, err := strconv.Atoi(n); err == nil {
+				index[k] = i
+				names = append(names, k)
+			}
+		}
+	}
+	sort.Slice(names, func(i, j int) bool { return index[names[i]] < index[names[j]] })
+	return names
+}
+
+// roundUpMB rounds n bytes up to a whole number of megabytes.
+func roundUpMB(n int64) int64 {
+	const mb = 1024 * 1024
+	return (n + mb - 1) / mb * mb
+}
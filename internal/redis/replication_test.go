@@ -0,0 +1,162 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReplicaFields(t *testing.T) {
+	repl := map[string]string{"role": "master", "slaves": "x"}
+	for i := 0; i < 12; i++ {
+		repl[fmt.Sprintf("slave%d", i)] = "ip=10.0.0.1"
+	}
+	names := replicaFields(repl)
+	if len(names) != 12 {
+		t.Fatalf("expected 12 replica fields, got %v", names)
+	}
+	for i, name := range names {
+		if want := fmt.Sprintf("slave%d", i); name != want {
+			t.Errorf("field %d: expected %s, got %s", i, want, name)
+		}
+	}
+}
+
+func TestReplicationScanner_Name(t *testing.T) {
+	s := &ReplicationScanner{}
+	if s.Name() != "replication" {
+		t.Errorf("expected name 'replication', got %q", s.Name())
+	}
+}
+
+func TestReplicationScanner_Primary(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["replication"] = "# Replication\nrole:master\nconnected_slaves:2\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=99000000,lag=0\n" +
+		"slave1:ip=10.0.0.3,port=6379,state=online,offset=90000000,lag=12\n" +
+		"master_repl_offset:100000000\nrepl_backlog_active:1\nrepl_backlog_size:1048576\n"
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:3600\n"
+	mock.infoResponses["stats"] = "# Stats\nsync_full:4\nsync_partial_ok:1\nsync_partial_err:3\n"
+	mock.configValues["min-replicas-to-write"] = map[string]string{"min-replicas-to-write": "0"}
+
+	s := &ReplicationScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "10.0.0.1:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+
+	lag := byID[FindingReplicaLag]
+	if len(lag) != 1 {
+		t.Fatalf("expected 1 lagging replica, got %d", len(lag))
+	}
+	if lag[0].ResourceID != "10.0.0.3:6379" || lag[0].Severity != SeverityHigh {
+		t.Errorf("expected high lag finding for 10.0.0.3:6379, got %s %s", lag[0].ResourceID, lag[0].Severity)
+	}
+
+	backlog := byID[FindingReplBacklogTooSmall]
+	if len(backlog) != 1 {
+		t.Fatalf("expected backlog finding, got %d", len(backlog))
+	}
+	// 100 MB over 3600s is ~28 KB/s; 60s needs ~1.7 MB, suggestion doubles it.
+	if got := backlog[0].Metadata["suggested_backlog_config"]; got != "repl-backlog-size 4mb" {
+		t.Errorf("unexpected suggestion %v", got)
+	}
+
+	if len(byID[FindingMinReplicasNotSet]) != 1 {
+		t.Errorf("expected MIN_REPLICAS_NOT_SET finding")
+	}
+	if len(byID[FindingPartialSyncFailures]) != 1 {
+		t.Errorf("expected PARTIAL_SYNC_FAILURES finding")
+	}
+}
+
+func TestReplicationScanner_BacklogSinceLastAudit(t *testing.T) {
+	now := time.Now()
+	repl := "# Replication\nrole:master\nconnected_slaves:1\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=900000000000,lag=0\n" +
+		"master_repl_offset:900000000000\nrepl_backlog_active:1\nrepl_backlog_size:1048576\n"
+	info := ParseInfo("run_id:abc\nuptime_in_seconds:864000\n" + repl)
+	previous := NewSnapshot(info, now.Add(-1000*time.Second))
+	previous.Uptime -= 1000
+	// 10 KB/s since the last audit fits the backlog, although the lifetime
+	// offset averaged over uptime would suggest ~1 MB/s.
+	previous.Counters["master_repl_offset"] -= 10000 * 1000
+
+	mock := newMockClient()
+	mock.infoResponses["replication"] = repl
+	mock.infoResponses["server"] = "# Server\nrun_id:abc\nuptime_in_seconds:864000\n"
+	cfg := AuditConfig{Addr: "10.0.0.1:6379", counters: NewCounters(NewSnapshot(info, now), &previous)}
+
+	findings, err := (&ReplicationScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if backlog := findingsByID(findings)[FindingReplBacklogTooSmall]; len(backlog) != 0 {
+		t.Errorf("expected the delta rate to fit the backlog, got %+v", backlog)
+	}
+}
+
+func TestReplicationScanner_BacklogAfterRestart(t *testing.T) {
+	mock := newMockClient()
+	// A 100 GB offset carried over a restart two minutes ago.
+	mock.infoResponses["replication"] = "# Replication\nrole:master\nconnected_slaves:1\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=100000000000,lag=0\n" +
+		"master_repl_offset:100000000000\nrepl_backlog_active:1\nrepl_backlog_size:1048576\n"
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:120\n"
+	cfg := AuditConfig{Addr: "10.0.0.1:6379", skipped: &skipList{}}
+
+	findings, err := (&ReplicationScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if backlog := findingsByID(findings)[FindingReplBacklogTooSmall]; len(backlog) != 0 {
+		t.Errorf("expected no backlog estimate right after a restart, got %+v", backlog)
+	}
+	if len(cfg.skipped.checks) != 1 || cfg.skipped.checks[0].Check != string(FindingReplBacklogTooSmall) {
+		t.Errorf("expected the backlog check to be skipped, got %v", cfg.skipped.checks)
+	}
+}
+
+func TestReplicationScanner_ReplicaDown(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["replication"] = "# Replication\nrole:slave\nmaster_host:10.0.0.1\nmaster_port:6379\nmaster_link_status:down\nmaster_link_down_since_seconds:120\n"
+	mock.configValues["replica-read-only"] = map[string]string{"replica-read-only": "no"}
+
+	s := &ReplicationScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "10.0.0.2:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+
+	down := byID[FindingReplicaLinkDown]
+	if len(down) != 1 || down[0].Severity != SeverityCritical {
+		t.Fatalf("expected critical REPLICA_LINK_DOWN, got %+v", down)
+	}
+	if down[0].Metadata["master"] != "10.0.0.1:6379" {
+		t.Errorf("unexpected master %v", down[0].Metadata["master"])
+	}
+	if len(byID[FindingReplicaWritable]) != 1 {
+		t.Errorf("expected REPLICA_WRITABLE finding")
+	}
+}
+
+func TestReplicationScanner_Healthy(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["replication"] = "# Replication\nrole:master\nconnected_slaves:1\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=5000,lag=0\n" +
+		"master_repl_offset:5000\nrepl_backlog_size:1048576\n"
+	mock.infoResponses["server"] = "# Server\nuptime_in_seconds:86400\n"
+	mock.configValues["min-replicas-to-write"] = map[string]string{"min-replicas-to-write": "1"}
+
+	s := &ReplicationScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d: %+v", len(findings), findings)
+	}
+}
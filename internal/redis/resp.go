@@ -0,0 +1,84 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Replies to commands sent with Do arrive as RESP2 flat arrays of
+// alternating names and values, or as RESP3 maps, depending on the protocol
+// the server negotiated. These helpers normalize both shapes.
+
+// replyMap converts a map-like reply into a map keyed by field name. It
+// accepts RESP3 maps and RESP2 arrays of alternating names and values.
+func replyMap(v any) (map[string]any, bool) {
+	switch t := v.(type) {
+	case map[string]any:
+		return t, true
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, val := range t {
+			m[fmt.Sprint(k)] = val
+		}
+		return m, true
+	case []any:
+		if len(t)%2 != 0 {
+			return nil, false
+		}
+		m := make(map[string]any, len(t)/2)
+		for i := 0; i < len(t); i += 2 {
+			k, ok := t[i].(string)
+			if !ok {
+				return nil, false
+			}
+			m[k] = t[i+1]
+		}
+		return m, true
+	}
+	return nil, false
+}
+
+// replyInt returns an integer reply value; numeric strings and doubles are
+// converted, anything else is 0.
+func replyInt(v any) int64 {
+	switch t := v.(type) {
+	case int64:
+		return t
+	case float64:
+		return int64(t)
+	case string:
+		if n, err := strconv.ParseInt(t, 10, 64); err == nil {
+			return n
+		}
+		f, _ := strconv.ParseFloat(t, 64)
+		return int64(f)
+	}
+	return 0
+}
+
+// replyFloat returns a floating-point reply value. RESP2 sends doubles as
+// bulk strings.
+func replyFloat(v any) float64 {
+	switch t := v.(type) {
+	case float64:
+		return t
+	case int64:
+		return float64(t)
+	case string:
+		f, _ := strconv.ParseFloat(t, 64)
+		return f
+	}
+	return 0
+}
+
+// replyString returns a string reply value, formatting numbers.
+func replyString(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	default:
+		return fmt.Sprint(t)
+	}
+}
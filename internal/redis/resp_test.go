@@ -0,0 +1,37 @@
+package redis
+
+import "testing"
+
+func TestReplyMap(t *testing.T) {
+	resp2 := []any{"keys.count", int64(10), "dataset.percentage", "42.5"}
+	resp3 := map[any]any{"keys.count": int64(10), "dataset.percentage": 42.5}
+
+	for name, reply := range map[string]any{"resp2": resp2, "resp3": resp3} {
+		m, ok := replyMap(reply)
+		if !ok {
+			t.Fatalf("%s: expected a map", name)
+		}
+		if replyInt(m["keys.count"]) != 10 {
+			t.Errorf("%s: expected keys.count 10, got %v", name, m["keys.count"])
+		}
+		if replyFloat(m["dataset.percentage"]) != 42.5 {
+			t.Errorf("%s: expected dataset.percentage 42.5, got %v", name, m["dataset.percentage"])
+		}
+	}
+
+	if _, ok := replyMap([]any{"odd"}); ok {
+		t.Error("expected odd-length array to be rejected")
+	}
+	if _, ok := replyMap("text"); ok {
+		t.Error("expected string to be rejected")
+	}
+}
+
+func TestReplyScalars(t *testing.T) {
+	if replyInt("12") != 12 || replyInt(3.9) != 3 || replyInt(nil) != 0 {
+		t.Error("unexpected replyInt results")
+	}
+	if replyString(int64(5)) != "5" || replyString(nil) != "" || replyString("x") != "x" {
+		t.Error("unexpected replyString results")
+	}
+}
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RestartScanner reports server restarts, which reset the counters other
+// auditors judge.
+type RestartScanner struct{}
+
+func (s *RestartScanner) Name() string { return "restarts" }
+
+func (s *RestartScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	counters := countersFor(cfg, ParseInfo(raw))
+	uptime := counters.Uptime()
+
+	meta := map[string]any{
+		"uptime_seconds": int64(uptime.Seconds()),
+		"run_id":         counters.current.RunID,
+	}
+
+	switch {
+	case counters.RestartedSinceLastAudit():
+		meta["previous_run_id"] = counters.previous.RunID
+		meta["previous_audit"] = counters.previous.Time.UTC().Format(time.RFC3339)
+		return []Finding{{
+			ID:           FindingServerRestarted,
+			Severity:     SeverityMedium,
+			ResourceType: "Redis",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("server restarted since the last audit (up %s); counters restart from zero", formatUptime(uptime)),
+			Metadata:     meta,
+		}}, nil
+	case counters.RecentlyRestarted():
+		return []Finding{{
+			ID:           FindingServerRestarted,
+			Severity:     SeverityLow,
+			ResourceType: "Redis",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("server restarted %ds ago; counter-based checks cover only this period", int64(uptime.Seconds())),
+			Metadata:     meta,
+		}}, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,54 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRestartScanner_Name(t *testing.T) {
+	s := &RestartScanner{}
+	if s.Name() != "restarts" {
+		t.Errorf("expected name 'restarts', got %q", s.Name())
+	}
+}
+
+func TestRestartScanner(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   string
+		previous *Snapshot
+		want     Severity
+	}{
+		{"long uptime", "run_id:abc\r\nuptime_in_seconds:864000\r\n", nil, ""},
+		{"recent restart", "run_id:abc\r\nuptime_in_seconds:120\r\n", nil, SeverityLow},
+		{"restart since last audit", "run_id:def\r\nuptime_in_seconds:86400\r\n",
+			&Snapshot{Time: time.Now().Add(-48 * time.Hour), RunID: "abc", Uptime: 500000}, SeverityMedium},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.infoResponses["server"] = tt.server
+			snap, err := TakeSnapshot(context.Background(), mock)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cfg := AuditConfig{counters: NewCounters(snap, tt.previous)}
+
+			s := &RestartScanner{}
+			findings, err := s.Audit(context.Background(), mock, cfg)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("expected 0 findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].ID != FindingServerRestarted || findings[0].Severity != tt.want {
+				t.Errorf("expected %s SERVER_RESTARTED, got %+v", tt.want, findings)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultSampleSize = 10000
+	scanBatchSize     = 100
+)
+
+// forEachSampledKey walks up to sampleSize keys with SCAN and calls fn for
+// each one. Returning false from fn stops the walk early.
+func forEachSampledKey(ctx context.Context, client RedisClient, sampleSize int, fn func(key string) bool) error {
+	if sampleSize <= 0 {
+		sampleSize = defaultSampleSize
+	}
+
+	sampled := 0
+	var cursor uint64
+
+	for sampled < sampleSize {
+		batchSize := int64(scanBatchSize)
+		if remaining := sampleSize - sampled; remaining < int(batchSize) {
+			batchSize = int64(remaining)
+		}
+
+		keys, nextCursor, err := client.Scan(ctx, cursor, "*", batchSize)
+		if err != nil {
+			return fmt.Errorf("scan keys: %w", err)
+		}
+
+		for _, key := range keys {
+			if !fn(key) {
+				return nil
+			}
+		}
+
+		sampled += len(keys)
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+	}
+
+	return nil
+}
+
+// keyNamespace returns the first colon-delimited segment of a key, which is
+// how most applications scope their keys ("session:abc" -> "session").
+func keyNamespace(key string) string {
+	if i := strings.IndexByte(key, ':'); i > 0 {
+		return key[:i]
+	}
+	return "(none)"
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"sort"
+	"strings"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
@@ -39,11 +41,41 @@ func (m *MultiAuditor) AuditAll(ctx context.Context, client RedisClient, cfg Aud
 		combined ScanResult
 	)
 
+	cfg.inventory = &inventory{data: make(map[string]any)}
+	cfg.skipped = &skipList{}
+	if cfg.Profile != nil {
+		cfg.inventory.set("profile", cfg.Profile.summary())
+	}
+
+	if snap, err := TakeSnapshot(ctx, client); err != nil {
+		slog.Warn("Counter snapshot failed; counter-based findings use cumulative values", "error", err)
+	} else {
+		if cfg.SampleWindow > 0 {
+			slog.Info("Sampling counters", "window", cfg.SampleWindow)
+			if w, err := SampleWindow(ctx, client, snap, cfg.SampleWindow, cfg.SampleInterval); err != nil {
+				slog.Warn("Sample window failed; rate-based findings skipped", "error", err)
+			} else {
+				cfg.window = w
+				snap = w.Last()
+				cfg.inventory.set("sample_window", w.summary())
+			}
+		}
+		cfg.counters = NewCounters(snap, cfg.PreviousSnapshot)
+		combined.Snapshot = &snap
+	}
+
 	g, ctx := errgroup.WithContext(ctx)
 	g.SetLimit(m.concurrency)
 
 	for _, auditor := range m.auditors {
 		a := auditor
+		if pc, ok := a.(profileChecker); ok {
+			if reason, ok := pc.Applicable(cfg.Profile); !ok {
+				slog.Debug("Auditor not applicable", "name", a.Name(), "reason", reason)
+				cfg.skipped.add(SkippedCheck{Auditor: a.Name(), Reason: reason})
+				continue
+			}
+		}
 		g.Go(func() error {
 			slog.Debug("Running auditor", "name", a.Name())
 
@@ -67,6 +99,17 @@ func (m *MultiAuditor) AuditAll(ctx context.Context, client RedisClient, cfg Aud
 		return nil, err
 	}
 
+	combined.Findings = attachFragmentationDiagnosis(combined.Findings, cfg.inventory)
+
+	combined.Skipped = cfg.skipped.checks
+	sort.SliceStable(combined.Skipped, func(i, j int) bool {
+		return combined.Skipped[i].Auditor < combined.Skipped[j].Auditor
+	})
+
+	if len(cfg.inventory.data) > 0 {
+		combined.Inventory = cfg.inventory.data
+	}
+
 	return &combined, nil
 }
 
@@ -74,11 +117,69 @@ func (m *MultiAuditor) AuditAll(ctx context.Context, client RedisClient, cfg Aud
 func AllAuditors() []Auditor {
 	return []Auditor{
 		&MemoryScanner{},
+		&MemoryOverheadScanner{},
 		&IdleKeyScanner{},
 		&BigKeyScanner{},
 		&ConnectionScanner{},
 		&EvictionScanner{},
+		&CacheScanner{},
 		&PersistenceScanner{},
 		&SlowLogScanner{},
+		&CommandStatsScanner{},
+		&LatencyScanner{},
+		&NamingScanner{},
+		&OrphanScanner{},
+		&SecurityScanner{},
+		&ACLUserScanner{},
+		&ReplicationScanner{},
+		&RestartScanner{},
+		&VersionScanner{},
+		&ModuleScanner{},
+		&ClusterScanner{},
+	}
+}
+
+// OptionalAuditors returns auditors that only run when enabled by name,
+// because they read key values or are otherwise expensive.
+func OptionalAuditors() []Auditor {
+	return []Auditor{
+		&DuplicateValueScanner{},
+		&CompressibilityScanner{},
+		&FormatScanner{},
+		&MallocStatsScanner{},
+	}
+}
+
+// SelectAuditors returns the default auditors plus the optional auditors
+// named in enabled.
+func SelectAuditors(enabled []string) ([]Auditor, error) {
+	auditors := AllAuditors()
+	if len(enabled) == 0 {
+		return auditors, nil
+	}
+
+	optional := make(map[string]Auditor)
+	for _, a := range OptionalAuditors() {
+		optional[a.Name()] = a
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range enabled {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		a, ok := optional[name]
+		if !ok {
+			names := make([]string, 0, len(optional))
+			for n := range optional {
+				names = append(names, n)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("unknown optional auditor %q (available: %s)", name, strings.Join(names, ", "))
+		}
+		seen[name] = true
+		auditors = append(auditors, a)
 	}
+	return auditors, nil
 }
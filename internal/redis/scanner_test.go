@@ -18,7 +18,7 @@ func TestMultiAuditorEmpty(t *testing.T) {
 
 func TestMultiAuditorCombinesFindings(t *testing.T) {
 	mock := newMockClient()
-	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:2.5\nused_memory:1000\nused_memory_rss:2500\nmaxmemory:0\n"
+	mock.infoResponses["memory"] = "# Memory\nmem_fragmentation_ratio:2.5\nused_memory:1073741824\nused_memory_rss:2684354560\nmaxmemory:0\n"
 	mock.configValues["maxmemory-policy"] = map[string]string{"maxmemory-policy": "allkeys-lru"}
 	mock.configValues["save"] = map[string]string{"save": ""}
 	mock.configValues["appendonly"] = map[string]string{"appendonly": "no"}
@@ -54,10 +54,49 @@ func TestMultiAuditorHandlesErrors(t *testing.T) {
 	}
 }
 
+func TestMultiAuditorCollectsInventory(t *testing.T) {
+	mock := newMockClient()
+	mock.aclList = []string{"user default on #abc ~* &* +@all"}
+
+	multi := NewMultiAuditor([]Auditor{&ACLUserScanner{}}, 1)
+	result, err := multi.AuditAll(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := result.Inventory["acl_users"]; !ok {
+		t.Errorf("expected acl_users in inventory, got %v", result.Inventory)
+	}
+}
+
 func TestAllAuditors(t *testing.T) {
 	auditors := AllAuditors()
-	if len(auditors) != 7 {
-		t.Errorf("expected 7 auditors, got %d", len(auditors))
+	if len(auditors) != 20 {
+		t.Errorf("expected 20 auditors, got %d", len(auditors))
+	}
+}
+
+func TestSelectAuditors(t *testing.T) {
+	auditors, err := SelectAuditors(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(auditors) != len(AllAuditors()) {
+		t.Errorf("expected only default auditors, got %d", len(auditors))
+	}
+
+	auditors, err = SelectAuditors([]string{"duplicates", "duplicates"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(auditors) != len(AllAuditors())+1 {
+		t.Errorf("expected defaults plus duplicates, got %d", len(auditors))
+	}
+	if auditors[len(auditors)-1].Name() != "duplicates" {
+		t.Errorf("expected last auditor 'duplicates', got %q", auditors[len(auditors)-1].Name())
+	}
+
+	if _, err := SelectAuditors([]string{"nope"}); err == nil {
+		t.Error("expected error for unknown auditor")
 	}
 }
 
@@ -0,0 +1,215 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// dangerousCommands can wipe data, reconfigure the server, or load code, and
+// should be renamed or denied by ACL for application users.
+var dangerousCommands = []string{"flushall", "config", "debug", "keys", "module"}
+
+// SecurityScanner audits authentication, network exposure, and dangerous
+// command availability.
+type SecurityScanner struct{}
+
+func (s *SecurityScanner) Name() string { return "security" }
+
+// configChecks are the findings that need CONFIG GET; without it they are
+// skipped and the ACL and COMMAND based checks still run.
+var configChecks = []FindingID{
+	FindingProtectedModeOff,
+	FindingBindAllInterfaces,
+	FindingDebugCommandEnabled,
+	FindingModuleCommandEnabled,
+}
+
+func (s *SecurityScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	var findings []Finding
+
+	conf := make(map[string]string)
+	if cfg.supports(CapConfig) {
+		for _, param := range []string{"requirepass", "protected-mode", "bind", "enable-debug-command", "enable-module-command"} {
+			vals, err := client.ConfigGet(ctx, param)
+			if err != nil {
+				return nil, fmt.Errorf("config get %s: %w", param, err)
+			}
+			for k, v := range vals {
+				conf[k] = v
+			}
+		}
+	} else {
+		for _, id := range configChecks {
+			cfg.skip(s.Name(), string(id), CapConfig)
+		}
+	}
+
+	// ACL LIST fails on servers older than 6.0, where requirepass is the
+	// only authentication.
+	var defaultUser *ACLUser
+	if lines, err := client.ACLList(ctx); err == nil {
+		for _, line := range lines {
+			if u, err := ParseACLUser(line); err == nil && u.Name == "default" {
+				defaultUser = &u
+				break
+			}
+		}
+	}
+
+	requirepass, hasRequirepass := conf["requirepass"]
+	noAuth := false
+	switch {
+	case defaultUser != nil:
+		noAuth = defaultUser.Enabled && defaultUser.NoPass
+	case hasRequirepass:
+		noAuth = requirepass == ""
+	case !cfg.supports(CapConfig):
+		// Neither ACL LIST nor CONFIG: authentication cannot be judged.
+		cfg.skip(s.Name(), string(FindingNoAuthentication), CapConfig)
+	}
+
+	if noAuth {
+		findings = append(findings, Finding{
+			ID:           FindingNoAuthentication,
+			Severity:     SeverityCritical,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      "no authentication: requirepass is empty and the default user has nopass",
+			Metadata: map[string]any{
+				"requirepass_set":     requirepass != "",
+				"default_user_nopass": defaultUser != nil && defaultUser.NoPass,
+				"recommendation":      "set requirepass or give the default user a password (ACL SETUSER default on >password), or disable it",
+			},
+		})
+	}
+
+	if mode, ok := conf["protected-mode"]; ok && mode == "no" {
+		severity := SeverityLow
+		if noAuth {
+			// Without auth, protected mode is the last guard against remote access.
+			severity = SeverityHigh
+		}
+		findings = append(findings, Finding{
+			ID:           FindingProtectedModeOff,
+			Severity:     severity,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      "protected-mode is disabled",
+			Metadata: map[string]any{
+				"protected-mode": mode,
+				"authenticated":  !noAuth,
+				"recommendation": "set protected-mode yes unless remote access is required and authentication is enabled",
+			},
+		})
+	}
+
+	if bind, ok := conf["bind"]; ok && bindsAllInterfaces(bind) {
+		severity := SeverityMedium
+		if noAuth {
+			severity = SeverityHigh
+		}
+		findings = append(findings, Finding{
+			ID:           FindingBindAllInterfaces,
+			Severity:     severity,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("listening on all interfaces (bind %q)", bind),
+			Metadata: map[string]any{
+				"bind":           bind,
+				"authenticated":  !noAuth,
+				"recommendation": "bind only to private interfaces, e.g. bind 127.0.0.1 10.0.0.5",
+			},
+		})
+	}
+
+	for _, param := range []string{"enable-debug-command", "enable-module-command"} {
+		value, ok := conf[param]
+		if !ok || value == "no" || value == "" {
+			continue
+		}
+		id := FindingDebugCommandEnabled
+		if param == "enable-module-command" {
+			id = FindingModuleCommandEnabled
+		}
+		severity := SeverityHigh
+		if value == "local" {
+			severity = SeverityLow
+		}
+		findings = append(findings, Finding{
+			ID:           id,
+			Severity:     severity,
+			ResourceType: "Config",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("%s is %q", param, value),
+			Metadata: map[string]any{
+				param:            value,
+				"recommendation": fmt.Sprintf("set %s no (or local for loopback-only access)", param),
+			},
+		})
+	}
+
+	exposed, err := s.exposedCommands(ctx, client, defaultUser)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range exposed {
+		severity := SeverityHigh
+		if cmd == "keys" {
+			severity = SeverityMedium
+		}
+		findings = append(findings, Finding{
+			ID:           FindingDangerousCommand,
+			Severity:     severity,
+			ResourceType: "Command",
+			ResourceID:   strings.ToUpper(cmd),
+			Message:      fmt.Sprintf("dangerous command %s is neither renamed nor ACL-restricted", strings.ToUpper(cmd)),
+			Metadata: map[string]any{
+				"command":        strings.ToUpper(cmd),
+				"recommendation": fmt.Sprintf("deny it for application users (ACL SETUSER default -%s) or rename it with rename-command", cmd),
+			},
+		})
+	}
+
+	return findings, nil
+}
+
+// exposedCommands returns the dangerous commands that exist under their
+// original name and that the default user may run.
+func (s *SecurityScanner) exposedCommands(ctx context.Context, client RedisClient, defaultUser *ACLUser) ([]string, error) {
+	if defaultUser != nil && !defaultUser.Enabled {
+		return nil, nil
+	}
+
+	commands, err := client.Commands(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("command: %w", err)
+	}
+
+	var exposed []string
+	for _, cmd := range dangerousCommands {
+		categories, ok := commands[cmd]
+		if !ok {
+			// Renamed or removed with rename-command.
+			continue
+		}
+		if defaultUser != nil && !defaultUser.Allows(cmd, categories) {
+			continue
+		}
+		exposed = append(exposed, cmd)
+	}
+	return exposed, nil
+}
+
+func bindsAllInterfaces(bind string) bool {
+	if strings.TrimSpace(bind) == "" {
+		return true
+	}
+	for _, addr := range strings.Fields(bind) {
+		switch strings.TrimPrefix(addr, "-") {
+		case "0.0.0.0", "*", "::", "::*":
+			return true
+		}
+	}
+	return false
+}
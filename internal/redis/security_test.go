@@ -0,0 +1,142 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestSecurityScanner_Name(t *testing.T) {
+	s := &SecurityScanner{}
+	if s.Name() != "security" {
+		t.Errorf("expected name 'security', got %q", s.Name())
+	}
+}
+
+func insecureMock() *mockClient {
+	mock := newMockClient()
+	mock.configValues["requirepass"] = map[string]string{"requirepass": ""}
+	mock.configValues["protected-mode"] = map[string]string{"protected-mode": "no"}
+	mock.configValues["bind"] = map[string]string{"bind": "* -::*"}
+	mock.configValues["enable-debug-command"] = map[string]string{"enable-debug-command": "yes"}
+	mock.configValues["enable-module-command"] = map[string]string{"enable-module-command": "local"}
+	mock.aclList = []string{"user default on nopass sanitize-payload ~* &* +@all"}
+	mock.commands = map[string][]string{
+		"get":      {"@read", "@string", "@fast"},
+		"flushall": {"@keyspace", "@write", "@slow", "@dangerous"},
+		"config":   {"@admin", "@slow", "@dangerous"},
+		"debug":    {"@admin", "@slow", "@dangerous"},
+		"keys":     {"@keyspace", "@read", "@slow", "@dangerous"},
+		"module":   {"@admin", "@slow", "@dangerous"},
+	}
+	return mock
+}
+
+func findingsByID(findings []Finding) map[FindingID][]Finding {
+	m := make(map[FindingID][]Finding)
+	for _, f := range findings {
+		m[f.ID] = append(m[f.ID], f)
+	}
+	return m
+}
+
+func TestSecurityScanner_Insecure(t *testing.T) {
+	s := &SecurityScanner{}
+	findings, err := s.Audit(context.Background(), insecureMock(), AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+
+	tests := []struct {
+		id       FindingID
+		count    int
+		severity Severity
+	}{
+		{FindingNoAuthentication, 1, SeverityCritical},
+		{FindingProtectedModeOff, 1, SeverityHigh},
+		{FindingBindAllInterfaces, 1, SeverityHigh},
+		{FindingDebugCommandEnabled, 1, SeverityHigh},
+		{FindingModuleCommandEnabled, 1, SeverityLow},
+		{FindingDangerousCommand, 5, ""},
+	}
+	for _, tt := range tests {
+		got := byID[tt.id]
+		if len(got) != tt.count {
+			t.Errorf("%s: expected %d findings, got %d", tt.id, tt.count, len(got))
+			continue
+		}
+		if tt.severity != "" && got[0].Severity != tt.severity {
+			t.Errorf("%s: expected severity %q, got %q", tt.id, tt.severity, got[0].Severity)
+		}
+	}
+}
+
+func TestSecurityScanner_Hardened(t *testing.T) {
+	mock := insecureMock()
+	mock.configValues["requirepass"] = map[string]string{"requirepass": "secret"}
+	mock.configValues["protected-mode"] = map[string]string{"protected-mode": "yes"}
+	mock.configValues["bind"] = map[string]string{"bind": "127.0.0.1 10.0.0.5"}
+	mock.configValues["enable-debug-command"] = map[string]string{"enable-debug-command": "no"}
+	mock.configValues["enable-module-command"] = map[string]string{"enable-module-command": "no"}
+	mock.aclList = []string{"user default on #abc ~* &* +@all -@dangerous"}
+
+	s := &SecurityScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected 0 findings, got %d: %+v", len(findings), findings)
+	}
+}
+
+func TestSecurityScanner_RenamedCommandsPreACL(t *testing.T) {
+	mock := insecureMock()
+	mock.configValues["requirepass"] = map[string]string{"requirepass": "secret"}
+	mock.aclErr = errors.New("ERR unknown command 'ACL'")
+	delete(mock.commands, "flushall")
+	delete(mock.commands, "config")
+
+	s := &SecurityScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	if len(byID[FindingNoAuthentication]) != 0 {
+		t.Error("expected no NO_AUTHENTICATION finding with requirepass set")
+	}
+	if len(byID[FindingDangerousCommand]) != 3 {
+		t.Errorf("expected 3 exposed commands (debug, keys, module), got %d", len(byID[FindingDangerousCommand]))
+	}
+	if byID[FindingProtectedModeOff][0].Severity != SeverityLow {
+		t.Errorf("expected low severity for protected-mode with auth, got %q", byID[FindingProtectedModeOff][0].Severity)
+	}
+}
+
+func TestSecurityScanner_WithoutConfig(t *testing.T) {
+	mock := newMockClient()
+	mock.configErr = errors.New("ERR unknown command 'CONFIG'")
+	mock.aclList = []string{"user default on nopass ~* &* +@all"}
+	mock.commands = map[string][]string{"flushall": {"@keyspace", "@write", "@slow", "@dangerous"}}
+	cfg := AuditConfig{
+		Profile: &Profile{Unavailable: map[Capability]string{CapConfig: "blocked"}},
+		skipped: &skipList{},
+	}
+
+	findings, err := (&SecurityScanner{}).Audit(context.Background(), mock, cfg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byID := findingsByID(findings)
+	if len(byID[FindingNoAuthentication]) != 1 {
+		t.Errorf("expected NO_AUTHENTICATION from ACL LIST, got %+v", findings)
+	}
+	if len(byID[FindingDangerousCommand]) != 1 {
+		t.Errorf("expected DANGEROUS_COMMAND_EXPOSED from COMMAND, got %+v", findings)
+	}
+	if len(cfg.skipped.checks) != len(configChecks) {
+		t.Errorf("expected the CONFIG checks to be skipped, got %+v", cfg.skipped.checks)
+	}
+}
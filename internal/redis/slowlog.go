@@ -3,11 +3,53 @@ package redis
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 )
 
-const slowThreshold = 10 * time.Millisecond
+const (
+	// defaultSlowThreshold is the slowlog entry duration reported as slow.
+	defaultSlowThreshold = 10 * time.Millisecond
+	// defaultSlowLogEntries is how many slowlog entries are read.
+	defaultSlowLogEntries = 128
+	// slowGroupHighP95 and slowGroupHighCount raise a group to high severity
+	// when it is both frequent and consistently slow.
+	slowGroupHighP95   = 100 * time.Millisecond
+	slowGroupHighCount = 10
+	// slowExampleMax caps the length of a group's example command.
+	slowExampleMax = 200
+	// slowGroupClients caps the client addresses listed per group.
+	slowGroupClients = 10
+	// slowlogMinMaxLen is the Redis default slowlog-max-len; shorter logs
+	// lose entries before an audit can read them.
+	slowlogMinMaxLen = 128
+	// slowlogMinSpan is the time a full slowlog should cover at least.
+	slowlogMinSpan = time.Hour
+	// slowKeySegmentMax is the key segment length above which a segment is
+	// treated as an identifier.
+	slowKeySegmentMax = 32
+)
+
+// containerCommands take a subcommand as their second argument.
+var containerCommands = map[string]bool{
+	"ACL": true, "CLIENT": true, "CLUSTER": true, "COMMAND": true, "CONFIG": true,
+	"DEBUG": true, "FUNCTION": true, "LATENCY": true, "MEMORY": true, "MODULE": true,
+	"OBJECT": true, "PUBSUB": true, "SCRIPT": true, "SLOWLOG": true, "XGROUP": true,
+	"XINFO": true,
+}
+
+// keylessCommands take no key argument.
+var keylessCommands = map[string]bool{
+	"AUTH": true, "BGREWRITEAOF": true, "BGSAVE": true, "DBSIZE": true, "ECHO": true,
+	"EXEC": true, "FLUSHALL": true, "FLUSHDB": true, "HELLO": true, "INFO": true,
+	"LASTSAVE": true, "MULTI": true, "PING": true, "PUBLISH": true, "RANDOMKEY": true,
+	"SAVE": true, "SCAN": true, "SELECT": true, "SHUTDOWN": true, "SWAPDB": true,
+	"TIME": true, "WAIT": true,
+}
 
 // SlowLogScanner audits Redis slow log for slow commands.
 type SlowLogScanner struct{}
@@ -15,35 +57,301 @@ type SlowLogScanner struct{}
 func (s *SlowLogScanner) Name() string { return "slowlog" }
 
 func (s *SlowLogScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
-	var findings []Finding
+	threshold := cfg.SlowLogThreshold
+	if threshold <= 0 {
+		threshold = defaultSlowThreshold
+	}
+	count := cfg.SlowLogEntries
+	if count <= 0 {
+		count = defaultSlowLogEntries
+	}
 
-	entries, err := client.SlowLogGet(ctx, 128)
+	entries, err := client.SlowLogGet(ctx, count)
 	if err != nil {
 		return nil, fmt.Errorf("slowlog get: %w", err)
 	}
 
-	for _, entry := range entries {
-		if entry.Duration >= slowThreshold {
-			command := strings.Join(entry.Args, " ")
-			if len(command) > 100 {
-				command = command[:100] + "..."
-			}
+	var findings []Finding
+	if cfg.supports(CapConfig) {
+		findings, err = s.configFindings(ctx, client, cfg, threshold, entries)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		cfg.skip(s.Name(), "slowlog configuration", CapConfig)
+	}
+
+	for _, g := range groupSlowLog(entries, threshold) {
+		findings = append(findings, g.finding(cfg))
+	}
+
+	return findings, nil
+}
 
-			findings = append(findings, Finding{
-				ID:           FindingSlowCommand,
-				Severity:     SeverityMedium,
-				ResourceType: "Redis",
-				ResourceID:   cfg.Addr,
-				Message:      fmt.Sprintf("slow command: %s (%.1f ms)", command, float64(entry.Duration.Microseconds())/1000),
-				Metadata: map[string]any{
-					"command":     strings.Join(entry.Args, " "),
-					"duration_ms": float64(entry.Duration.Microseconds()) / 1000,
-					"timestamp":   entry.Time.UTC().Format(time.RFC3339),
-					"slowlog_id":  entry.ID,
-				},
-			})
+// configFindings checks that the slowlog records what the audit looks for:
+// it is enabled, its threshold is not above the audit's, and it is long
+// enough not to wrap between audits.
+func (s *SlowLogScanner) configFindings(ctx context.Context, client RedisClient, cfg AuditConfig, threshold time.Duration, entries []SlowLogEntry) ([]Finding, error) {
+	slowerThanConfig, err := client.ConfigGet(ctx, "slowlog-log-slower-than")
+	if err != nil {
+		return nil, fmt.Errorf("config get slowlog-log-slower-than: %w", err)
+	}
+	maxLenConfig, err := client.ConfigGet(ctx, "slowlog-max-len")
+	if err != nil {
+		return nil, fmt.Errorf("config get slowlog-max-len: %w", err)
+	}
+
+	var findings []Finding
+	thresholdUsec := threshold.Microseconds()
+
+	if v, ok := slowerThanConfig["slowlog-log-slower-than"]; ok {
+		slowerThan, _ := strconv.ParseInt(v, 10, 64)
+		switch {
+		case slowerThan < 0:
+			findings = append(findings, slowlogConfigFinding(cfg, FindingSlowlogDisabled, SeverityMedium,
+				"slowlog is disabled (slowlog-log-slower-than -1); slow commands are not recorded",
+				map[string]any{
+					"slowlog_log_slower_than": slowerThan,
+					"suggested_config":        fmt.Sprintf("slowlog-log-slower-than %d", thresholdUsec),
+				}))
+		case slowerThan > thresholdUsec:
+			findings = append(findings, slowlogConfigFinding(cfg, FindingSlowlogMisconfigured, SeverityLow,
+				fmt.Sprintf("slowlog-log-slower-than is %s; commands between %s and %s are not recorded",
+					time.Duration(slowerThan)*time.Microsecond, threshold, time.Duration(slowerThan)*time.Microsecond),
+				map[string]any{
+					"slowlog_log_slower_than": slowerThan,
+					"threshold_usec":          thresholdUsec,
+					"suggested_config":        fmt.Sprintf("slowlog-log-slower-than %d", thresholdUsec),
+				}))
+		}
+	}
+
+	if v, ok := maxLenConfig["slowlog-max-len"]; ok {
+		maxLen, _ := strconv.ParseInt(v, 10, 64)
+		switch {
+		case maxLen <= 0:
+			findings = append(findings, slowlogConfigFinding(cfg, FindingSlowlogDisabled, SeverityMedium,
+				"slowlog-max-len is 0; slow commands are not kept",
+				map[string]any{
+					"slowlog_max_len":  maxLen,
+					"suggested_config": fmt.Sprintf("slowlog-max-len %d", slowlogMinMaxLen),
+				}))
+		case maxLen < slowlogMinMaxLen:
+			findings = append(findings, slowlogConfigFinding(cfg, FindingSlowlogMisconfigured, SeverityLow,
+				fmt.Sprintf("slowlog-max-len is %d; older slow commands are dropped before they can be reviewed", maxLen),
+				map[string]any{
+					"slowlog_max_len":  maxLen,
+					"suggested_config": fmt.Sprintf("slowlog-max-len %d", slowlogMinMaxLen),
+				}))
+		case int64(len(entries)) >= maxLen && len(entries) > 1:
+			span := slowLogSpan(entries)
+			if span < slowlogMinSpan {
+				findings = append(findings, slowlogConfigFinding(cfg, FindingSlowlogMisconfigured, SeverityLow,
+					fmt.Sprintf("slowlog is full and holds only the last %s of slow commands (slowlog-max-len %d)",
+						span.Round(time.Second), maxLen),
+					map[string]any{
+						"slowlog_max_len":  maxLen,
+						"span_seconds":     span.Seconds(),
+						"suggested_config": fmt.Sprintf("slowlog-max-len %d", maxLen*4),
+					}))
+			}
 		}
 	}
 
 	return findings, nil
 }
+
+func slowlogConfigFinding(cfg AuditConfig, id FindingID, severity Severity, message string, meta map[string]any) Finding {
+	return Finding{
+		ID:           id,
+		Severity:     severity,
+		ResourceType: "Config",
+		ResourceID:   cfg.Addr,
+		Message:      message,
+		Metadata:     meta,
+	}
+}
+
+// slowLogSpan returns the time between the oldest and newest entry.
+func slowLogSpan(entries []SlowLogEntry) time.Duration {
+	oldest, newest := entries[0].Time, entries[0].Time
+	for _, e := range entries[1:] {
+		if e.Time.Before(oldest) {
+			oldest = e.Time
+		}
+		if e.Time.After(newest) {
+			newest = e.Time
+		}
+	}
+	return newest.Sub(oldest)
+}
+
+// slowGroup aggregates slowlog entries sharing a command and key pattern.
+type slowGroup struct {
+	Command    string
+	KeyPattern string
+	Entries    []SlowLogEntry
+}
+
+// Signature is the normalized command: the command name and key pattern.
+func (g *slowGroup) Signature() string {
+	if g.KeyPattern == "" {
+		return g.Command
+	}
+	return g.Command + " " + g.KeyPattern
+}
+
+func (g *slowGroup) total() time.Duration {
+	var total time.Duration
+	for _, e := range g.Entries {
+		total += e.Duration
+	}
+	return total
+}
+
+// groupSlowLog groups entries at or above threshold by normalized command,
+// sorted by total time spent.
+func groupSlowLog(entries []SlowLogEntry, threshold time.Duration) []*slowGroup {
+	index := make(map[string]*slowGroup)
+	var groups []*slowGroup
+	for _, e := range entries {
+		if e.Duration < threshold || len(e.Args) == 0 {
+			continue
+		}
+		command, pattern := normalizeSlowCommand(e.Args)
+		key := command + "\x00" + pattern
+		g, ok := index[key]
+		if !ok {
+			g = &slowGroup{Command: command, KeyPattern: pattern}
+			index[key] = g
+			groups = append(groups, g)
+		}
+		g.Entries = append(g.Entries, e)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].total() > groups[j].total() })
+	return groups
+}
+
+func (g *slowGroup) finding(cfg AuditConfig) Finding {
+	durations := make([]time.Duration, len(g.Entries))
+	first, last := g.Entries[0].Time, g.Entries[0].Time
+	slowest := g.Entries[0]
+	clients := make(map[string]string)
+	ids := make([]int64, 0, len(g.Entries))
+	for i, e := range g.Entries {
+		durations[i] = e.Duration
+		if e.Time.Before(first) {
+			first = e.Time
+		}
+		if e.Time.After(last) {
+			last = e.Time
+		}
+		if e.ClientAddr != "" {
+			clients[e.ClientAddr] = e.ClientName
+		}
+		ids = append(ids, e.ID)
+		if e.Duration > slowest.Duration {
+			slowest = e
+		}
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	p50 := durationPercentile(durations, 0.5)
+	p95 := durationPercentile(durations, 0.95)
+	maxDuration := durations[len(durations)-1]
+
+	severity := SeverityMedium
+	if len(g.Entries) >= slowGroupHighCount && p95 >= slowGroupHighP95 {
+		severity = SeverityHigh
+	}
+
+	signature := g.Signature()
+	if len(signature) > 100 {
+		signature = signature[:100] + "..."
+	}
+
+	return Finding{
+		ID:           FindingSlowCommand,
+		Severity:     severity,
+		ResourceType: "Redis",
+		ResourceID:   cfg.Addr,
+		Message: fmt.Sprintf("slow command: %s (%d calls, p50 %.1f ms, p95 %.1f ms, max %.1f ms)",
+			signature, len(g.Entries), durationMs(p50), durationMs(p95), durationMs(maxDuration)),
+		Metadata: map[string]any{
+			"command":      g.Signature(),
+			"command_name": g.Command,
+			"key_pattern":  g.KeyPattern,
+			"count":        len(g.Entries),
+			"p50_ms":       durationMs(p50),
+			"p95_ms":       durationMs(p95),
+			"max_ms":       durationMs(maxDuration),
+			"first_seen":   first.UTC().Format(time.RFC3339),
+			"last_seen":    last.UTC().Format(time.RFC3339),
+			"clients":      slowGroupClientList(clients),
+			"slowlog_ids":  ids,
+			"example":      slowExample(slowest.Args, cfg.SlowLogArgs),
+		},
+	}
+}
+
+// slowExample renders the arguments of a group's slowest entry with
+// arguments redacted by mode.
+func slowExample(args []string, mode ArgRedaction) string {
+	example := strings.Join(RedactArgs(args, mode), " ")
+	if len(example) > slowExampleMax {
+		example = example[:slowExampleMax] + "..."
+	}
+	return example
+}
+
+// slowGroupClientList renders client addresses, with names when known.
+func slowGroupClientList(clients map[string]string) []string {
+	list := make([]string, 0, len(clients))
+	for addr, name := range clients {
+		if name != "" {
+			addr += " (" + name + ")"
+		}
+		list = append(list, addr)
+	}
+	sort.Strings(list)
+	if len(list) > slowGroupClients {
+		list = list[:slowGroupClients]
+	}
+	return list
+}
+
+// durationPercentile returns the nearest-rank percentile of sorted durations.
+func durationPercentile(sorted []time.Duration, q float64) time.Duration {
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(rank, 0)]
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1000
+}
+
+// normalizeSlowCommand returns the command name, with the subcommand for
+// container commands, and the pattern of its first key with identifiers
+// replaced by "*". Argument values are dropped.
+func normalizeSlowCommand(args []string) (command, pattern string) {
+	command = strings.ToUpper(args[0])
+	if containerCommands[command] && len(args) > 1 {
+		command += " " + strings.ToUpper(args[1])
+	}
+	if keys := commandKeyIndexes(args); len(keys) > 0 {
+		pattern = keyPattern(args[keys[0]])
+	}
+	return command, pattern
+}
+
+// keyPattern replaces colon-delimited key segments that look like
+// identifiers (containing a digit, or very long) with "*", so
+// "user:1001:profile" and "user:1002:profile" share "user:*:profile".
+func keyPattern(key string) string {
+	segments := strings.Split(key, ":")
+	for i, seg := range segments {
+		if len(seg) > slowKeySegmentMax || strings.IndexFunc(seg, unicode.IsDigit) >= 0 {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, ":")
+}
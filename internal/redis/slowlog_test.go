@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -93,3 +95,173 @@ func TestSlowLogScanner_TruncatesLongCommands(t *testing.T) {
 		t.Errorf("expected truncated message, got length %d", len(findings[0].Message))
 	}
 }
+
+func TestSlowLogScanner_GroupsByCommandAndKeyPattern(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := newMockClient()
+	for i := range 20 {
+		mock.slowLog = append(mock.slowLog, SlowLogEntry{
+			ID:         int64(i),
+			Time:       base.Add(time.Duration(i) * time.Minute),
+			Duration:   time.Duration(100+i*10) * time.Millisecond,
+			Args:       []string{"hgetall", fmt.Sprintf("user:%d:profile", i)},
+			ClientAddr: fmt.Sprintf("10.0.0.%d:5000", i%2),
+			ClientName: "api",
+		})
+	}
+	mock.slowLog = append(mock.slowLog,
+		SlowLogEntry{ID: 100, Time: base, Duration: 30 * time.Millisecond, Args: []string{"CONFIG", "GET", "*"}})
+
+	s := &SlowLogScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{Addr: "localhost:6379"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 2 {
+		t.Fatalf("expected 2 groups, got %+v", findings)
+	}
+
+	f := findings[0]
+	if f.Metadata["command"] != "HGETALL user:*:profile" {
+		t.Errorf("expected normalized command, got %v", f.Metadata["command"])
+	}
+	if f.Metadata["count"] != 20 {
+		t.Errorf("expected 20 calls, got %v", f.Metadata["count"])
+	}
+	if f.Metadata["p50_ms"] != 190.0 || f.Metadata["p95_ms"] != 280.0 || f.Metadata["max_ms"] != 290.0 {
+		t.Errorf("unexpected percentiles: p50 %v p95 %v max %v", f.Metadata["p50_ms"], f.Metadata["p95_ms"], f.Metadata["max_ms"])
+	}
+	if f.Severity != SeverityHigh {
+		t.Errorf("expected high severity for a frequent slow group, got %s", f.Severity)
+	}
+	if f.Metadata["first_seen"] != "2026-03-01T12:00:00Z" || f.Metadata["last_seen"] != "2026-03-01T12:19:00Z" {
+		t.Errorf("unexpected time span: %v - %v", f.Metadata["first_seen"], f.Metadata["last_seen"])
+	}
+	clients := f.Metadata["clients"].([]string)
+	if len(clients) != 2 || clients[0] != "10.0.0.0:5000 (api)" {
+		t.Errorf("unexpected clients: %v", clients)
+	}
+	if findings[1].Metadata["command"] != "CONFIG GET" {
+		t.Errorf("expected keyless container command, got %v", findings[1].Metadata["command"])
+	}
+}
+
+func TestSlowLogScanner_ConfigurableThreshold(t *testing.T) {
+	mock := newMockClient()
+	mock.slowLog = []SlowLogEntry{
+		{ID: 1, Time: time.Now(), Duration: 5 * time.Millisecond, Args: []string{"GET", "key1"}},
+	}
+
+	s := &SlowLogScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{SlowLogThreshold: 2 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 1 {
+		t.Errorf("expected 1 finding below the default threshold, got %d", len(findings))
+	}
+}
+
+func TestSlowLogScanner_Config(t *testing.T) {
+	now := time.Now()
+	full := []SlowLogEntry{
+		{ID: 1, Time: now, Duration: time.Millisecond, Args: []string{"GET", "a"}},
+		{ID: 2, Time: now.Add(-time.Minute), Duration: time.Millisecond, Args: []string{"GET", "b"}},
+	}
+	tests := []struct {
+		name       string
+		slowerThan string
+		maxLen     string
+		entries    []SlowLogEntry
+		want       FindingID
+	}{
+		{"defaults", "10000", "128", nil, ""},
+		{"disabled", "-1", "128", nil, FindingSlowlogDisabled},
+		{"zero length", "10000", "0", nil, FindingSlowlogDisabled},
+		{"threshold too high", "1000000", "128", nil, FindingSlowlogMisconfigured},
+		{"too short", "10000", "16", nil, FindingSlowlogMisconfigured},
+		{"wraps quickly", "10000", "128", full[:1], ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.configValues["slowlog-log-slower-than"] = map[string]string{"slowlog-log-slower-than": tt.slowerThan}
+			mock.configValues["slowlog-max-len"] = map[string]string{"slowlog-max-len": tt.maxLen}
+			mock.slowLog = tt.entries
+
+			s := &SlowLogScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.want == "" {
+				if len(findings) != 0 {
+					t.Errorf("expected no findings, got %+v", findings)
+				}
+				return
+			}
+			if len(findings) != 1 || findings[0].ID != tt.want {
+				t.Errorf("expected %s, got %+v", tt.want, findings)
+			}
+		})
+	}
+
+	mock := newMockClient()
+	mock.configValues["slowlog-max-len"] = map[string]string{"slowlog-max-len": "2"}
+	mock.slowLog = full
+	s := &SlowLogScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findingsByID(findings)[FindingSlowlogMisconfigured]) != 1 {
+		t.Errorf("expected short slowlog-max-len to be reported, got %+v", findings)
+	}
+}
+
+func TestNormalizeSlowCommand(t *testing.T) {
+	tests := []struct {
+		args    []string
+		command string
+		pattern string
+	}{
+		{[]string{"get", "session:9f8e7d6c"}, "GET", "session:*"},
+		{[]string{"SET", "cache:page:home", "<html>"}, "SET", "cache:page:home"},
+		{[]string{"EVALSHA", "abc", "1", "lock:order:42", "arg"}, "EVALSHA", "lock:order:*"},
+		{[]string{"EVAL", "return 1", "0"}, "EVAL", ""},
+		{[]string{"MEMORY", "USAGE", "user:7"}, "MEMORY USAGE", "user:*"},
+		{[]string{"AUTH", "secret"}, "AUTH", ""},
+		{[]string{"KEYS", "*"}, "KEYS", "*"},
+	}
+	for _, tt := range tests {
+		command, pattern := normalizeSlowCommand(tt.args)
+		if command != tt.command || pattern != tt.pattern {
+			t.Errorf("normalizeSlowCommand(%v) = %q %q, want %q %q", tt.args, command, pattern, tt.command, tt.pattern)
+		}
+	}
+}
+
+func TestSlowLogScanner_RedactsArguments(t *testing.T) {
+	mock := newMockClient()
+	mock.slowLog = []SlowLogEntry{
+		{ID: 1, Time: time.Now(), Duration: 20 * time.Millisecond, Args: []string{"SET", "card:4111", "4111111111111111"}},
+		{ID: 2, Time: time.Now(), Duration: 30 * time.Millisecond, Args: []string{"AUTH", "hunter2"}},
+	}
+
+	for _, mode := range []ArgRedaction{"", RedactLengths, RedactNone} {
+		s := &SlowLogScanner{}
+		findings, err := s.Audit(context.Background(), mock, AuditConfig{SlowLogArgs: mode})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, f := range findings {
+			text := fmt.Sprint(f.Message, f.Metadata)
+			if strings.Contains(text, "hunter2") {
+				t.Errorf("mode %q: password leaked in %s", mode, text)
+			}
+			if mode != RedactNone && strings.Contains(text, "4111111111111111") {
+				t.Errorf("mode %q: value leaked in %s", mode, text)
+			}
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package redis
 
+import (
+	"context"
+	"time"
+)
+
 // Severity levels for findings.
 type Severity string
 
@@ -51,13 +56,77 @@ func ParseSeverity(s string) Severity {
 type FindingID string
 
 const (
-	FindingHighFragmentation FindingID = "HIGH_FRAGMENTATION"
-	FindingIdleKey           FindingID = "IDLE_KEY"
-	FindingBigKey            FindingID = "BIG_KEY"
-	FindingConnectionWaste   FindingID = "CONNECTION_WASTE"
-	FindingEvictionRisk      FindingID = "EVICTION_RISK"
-	FindingNoPersistence     FindingID = "NO_PERSISTENCE"
-	FindingSlowCommand       FindingID = "SLOW_COMMAND"
+	FindingHighFragmentation      FindingID = "HIGH_FRAGMENTATION"
+	FindingIdleKey                FindingID = "IDLE_KEY"
+	FindingBigKey                 FindingID = "BIG_KEY"
+	FindingConnectionWaste        FindingID = "CONNECTION_WASTE"
+	FindingEvictionRisk           FindingID = "EVICTION_RISK"
+	FindingNoPersistence          FindingID = "NO_PERSISTENCE"
+	FindingSlowCommand            FindingID = "SLOW_COMMAND"
+	FindingSlowlogDisabled        FindingID = "SLOWLOG_DISABLED"
+	FindingSlowlogMisconfigured   FindingID = "SLOWLOG_MISCONFIGURED"
+	FindingDuplicateValue         FindingID = "DUPLICATE_VALUE"
+	FindingCompressibleValues     FindingID = "COMPRESSIBLE_VALUES"
+	FindingUnsafeSerialization    FindingID = "UNSAFE_SERIALIZATION"
+	FindingKeyNamingViolation     FindingID = "KEY_NAMING_VIOLATION"
+	FindingKeyNameBytes           FindingID = "KEY_NAME_BYTES"
+	FindingOrphanedNamespace      FindingID = "ORPHANED_NAMESPACE"
+	FindingNoAuthentication       FindingID = "NO_AUTHENTICATION"
+	FindingProtectedModeOff       FindingID = "PROTECTED_MODE_DISABLED"
+	FindingBindAllInterfaces      FindingID = "BIND_ALL_INTERFACES"
+	FindingDangerousCommand       FindingID = "DANGEROUS_COMMAND_EXPOSED"
+	FindingDebugCommandEnabled    FindingID = "DEBUG_COMMAND_ENABLED"
+	FindingModuleCommandEnabled   FindingID = "MODULE_COMMAND_ENABLED"
+	FindingACLOverprivileged      FindingID = "ACL_OVERPRIVILEGED_USER"
+	FindingACLNoPassword          FindingID = "ACL_USER_NO_PASSWORD"
+	FindingACLUserNotConnected    FindingID = "ACL_USER_NOT_CONNECTED"
+	FindingACLMultiplePasswords   FindingID = "ACL_MULTIPLE_PASSWORDS"
+	FindingACLDangerousAccess     FindingID = "ACL_DANGEROUS_ACCESS"
+	FindingReplicaLinkDown        FindingID = "REPLICA_LINK_DOWN"
+	FindingReplicaLag             FindingID = "REPLICA_LAG"
+	FindingReplBacklogTooSmall    FindingID = "REPL_BACKLOG_TOO_SMALL"
+	FindingReplicaWritable        FindingID = "REPLICA_WRITABLE"
+	FindingMinReplicasNotSet      FindingID = "MIN_REPLICAS_NOT_SET"
+	FindingPartialSyncFailures    FindingID = "PARTIAL_SYNC_FAILURES"
+	FindingRDBSaveFailing         FindingID = "RDB_SAVE_FAILING"
+	FindingAOFWriteFailing        FindingID = "AOF_WRITE_FAILING"
+	FindingStaleSnapshot          FindingID = "STALE_SNAPSHOT"
+	FindingAOFRewriteStuck        FindingID = "AOF_REWRITE_STUCK"
+	FindingAOFFsyncAlways         FindingID = "AOF_FSYNC_ALWAYS"
+	FindingSlowFork               FindingID = "SLOW_FORK"
+	FindingMaxmemoryNotSet        FindingID = "MAXMEMORY_NOT_SET"
+	FindingMaxmemoryOvercommit    FindingID = "MAXMEMORY_OVERCOMMIT"
+	FindingMaxmemoryOversized     FindingID = "MAXMEMORY_OVERSIZED"
+	FindingEvictionPolicyMismatch FindingID = "EVICTION_POLICY_MISMATCH"
+	FindingEvictionStorm          FindingID = "EVICTION_STORM"
+	FindingIdleConnections        FindingID = "IDLE_CONNECTIONS"
+	FindingClientBufferMemory     FindingID = "CLIENT_BUFFER_MEMORY"
+	FindingMaxclientsUtilization  FindingID = "MAXCLIENTS_UTILIZATION"
+	FindingConnectionChurn        FindingID = "CONNECTION_CHURN"
+	FindingLatencyMonitorOff      FindingID = "LATENCY_MONITOR_DISABLED"
+	FindingLatencySpike           FindingID = "LATENCY_SPIKE"
+	FindingMemoryOverhead         FindingID = "MEMORY_OVERHEAD"
+	FindingMemorySwapping         FindingID = "MEMORY_SWAPPING"
+	FindingFragmentationDiagnosis FindingID = "FRAGMENTATION_DIAGNOSIS"
+	FindingCommandAntipattern     FindingID = "COMMAND_ANTIPATTERN"
+	FindingHighCommandLatency     FindingID = "HIGH_COMMAND_LATENCY"
+	FindingCommandFailures        FindingID = "COMMAND_FAILURES"
+	FindingDominantErrors         FindingID = "DOMINANT_ERROR_TYPES"
+	FindingLowHitRatio            FindingID = "LOW_HIT_RATIO"
+	FindingEvictionChurn          FindingID = "EVICTION_CHURN"
+	FindingStaleExpiredKeys       FindingID = "STALE_EXPIRED_KEYS"
+	FindingServerRestarted        FindingID = "SERVER_RESTARTED"
+	FindingVersionEOL             FindingID = "VERSION_EOL"
+	FindingVersionVulnerable      FindingID = "VERSION_VULNERABLE"
+	FindingSearchIndexMemory      FindingID = "SEARCH_INDEX_MEMORY"
+	FindingSearchDeletedDocs      FindingID = "SEARCH_INDEX_DELETED_DOCS"
+	FindingSearchIndexUnused      FindingID = "SEARCH_INDEX_UNUSED"
+	FindingTimeSeriesUnbounded    FindingID = "TIMESERIES_UNBOUNDED"
+	FindingClusterSlotsUncovered  FindingID = "CLUSTER_SLOTS_UNCOVERED"
+	FindingClusterSlotMigrating   FindingID = "CLUSTER_SLOT_MIGRATING"
+	FindingClusterCoverageRisk    FindingID = "CLUSTER_FULL_COVERAGE_RISK"
+	FindingClusterShardImbalance  FindingID = "CLUSTER_SHARD_IMBALANCE"
+	FindingClusterHashTagHotspot  FindingID = "CLUSTER_HASH_TAG_HOTSPOT"
 )
 
 // Finding represents a single audit issue.
@@ -68,13 +137,21 @@ type Finding struct {
 	ResourceID   string         `json:"resource_id"`
 	Message      string         `json:"message"`
 	Metadata     map[string]any `json:"metadata,omitempty"`
+	Owner        *Owner         `json:"owner,omitempty"`
 }
 
 // ScanResult holds all findings from scanning a Redis instance.
 type ScanResult struct {
-	Findings         []Finding `json:"findings"`
-	Errors           []string  `json:"errors,omitempty"`
-	ResourcesScanned int       `json:"resources_scanned"`
+	Findings         []Finding      `json:"findings"`
+	Errors           []string       `json:"errors,omitempty"`
+	ResourcesScanned int            `json:"resources_scanned"`
+	Inventory        map[string]any `json:"inventory,omitempty"`
+	// Skipped lists auditors and checks that do not apply to the server.
+	Skipped []SkippedCheck `json:"skipped,omitempty"`
+
+	// Snapshot holds the counters taken at the start of the audit, for the
+	// state file; nil when INFO could not be read.
+	Snapshot *Snapshot `json:"-"`
 }
 
 // AuditConfig holds parameters that control auditing behavior.
@@ -84,4 +161,65 @@ type AuditConfig struct {
 	SampleSize int
 	IdleDays   int
 	BigKeySize int64
+
+	// LargeValueSize is the minimum value size inspected by value auditors.
+	LargeValueSize int64
+	// ValueBudget caps the value bytes each value auditor may read.
+	ValueBudget int64
+
+	// NamingRules and MaxKeyLength drive the key naming linter.
+	NamingRules  []NamingRule
+	MaxKeyLength int
+
+	// Owners maps key names to owning teams; nil disables orphan detection.
+	Owners *Owners
+
+	// IdleConnThreshold is the idle connection count per source IP, client
+	// name, or library above which the group is reported (default 100).
+	IdleConnThreshold int
+
+	// LatencyThreshold is the latency monitor event duration reported as a
+	// spike (default 100ms); LatencyEventThresholds overrides it per event.
+	LatencyThreshold       time.Duration
+	LatencyEventThresholds map[string]time.Duration
+
+	// PreviousSnapshot is the counters snapshot saved by the previous run for
+	// this target; counter-based auditors report deltas since it.
+	PreviousSnapshot *Snapshot
+
+	// SlowLogThreshold is the slowlog entry duration reported as slow
+	// (default 10ms); SlowLogEntries is how many entries are read (default
+	// 128).
+	SlowLogThreshold time.Duration
+	SlowLogEntries   int64
+	// SlowLogArgs controls how slowlog arguments appear in findings; the
+	// zero value keeps only command and key names.
+	SlowLogArgs ArgRedaction
+
+	// VersionData is the release end-of-life and CVE table; nil uses the
+	// table compiled into the binary.
+	VersionData *VersionData
+
+	// SampleWindow, when set, spaces two INFO snapshots this far apart (or
+	// more, every SampleInterval) so auditors can judge per-second rates.
+	SampleWindow   time.Duration
+	SampleInterval time.Duration
+
+	// DialShard connects to another node of a cluster by address, for the
+	// per-shard checks of the cluster auditor; nil limits that auditor to
+	// the node it is connected to. The auditor closes the clients it dials.
+	DialShard func(ctx context.Context, addr string) (RedisClient, error)
+	// ShardImbalanceRatio is the largest shard's key count, used memory, or
+	// ops rate over the shard average above which a cluster is reported as
+	// imbalanced (default 1.5).
+	ShardImbalanceRatio float64
+
+	// Profile is the server's compatibility profile; nil means every
+	// capability is available.
+	Profile *Profile
+
+	inventory *inventory
+	skipped   *skipList
+	counters  *Counters
+	window    *WindowRates
 }
@@ -0,0 +1,65 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+const (
+	defaultLargeValueSize = 64 * 1024        // 64 KB
+	defaultValueBudget    = 64 * 1024 * 1024 // 64 MB
+	valueChunkSize        = 1024 * 1024
+)
+
+// valueBudget caps how many value bytes a single auditor may read, so that
+// value-inspecting auditors stay cheap on instances with huge datasets.
+type valueBudget struct {
+	remaining int64
+}
+
+func newValueBudget(limit int64) *valueBudget {
+	if limit <= 0 {
+		limit = defaultValueBudget
+	}
+	return &valueBudget{remaining: limit}
+}
+
+// take reserves n bytes and reports whether the budget allowed it.
+func (b *valueBudget) take(n int64) bool {
+	if n > b.remaining {
+		return false
+	}
+	b.remaining -= n
+	return true
+}
+
+// streamStringValue copies up to length bytes of a string value into w using
+// GETRANGE chunks, so large values are never held in memory as a whole.
+func streamStringValue(ctx context.Context, client RedisClient, key string, length int64, w io.Writer) error {
+	for start := int64(0); start < length; start += valueChunkSize {
+		end := start + valueChunkSize - 1
+		if end >= length {
+			end = length - 1
+		}
+		chunk, err := client.GetRange(ctx, key, start, end)
+		if err != nil {
+			return fmt.Errorf("getrange %q: %w", key, err)
+		}
+		if _, err := io.WriteString(w, chunk); err != nil {
+			return err
+		}
+		if int64(len(chunk)) < end-start+1 {
+			// Value shrank since STRLEN; hash what we have.
+			return nil
+		}
+	}
+	return nil
+}
+
+func largeValueSize(cfg AuditConfig) int64 {
+	if cfg.LargeValueSize > 0 {
+		return cfg.LargeValueSize
+	}
+	return defaultLargeValueSize
+}
@@ -0,0 +1,328 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server flavors: Redis and the forks and reimplementations that speak its
+// protocol.
+const (
+	FlavorRedis     = "redis"
+	FlavorValkey    = "valkey"
+	FlavorKeyDB     = "keydb"
+	FlavorDragonfly = "dragonfly"
+)
+
+// eolSoonWindow is how far ahead an upcoming end-of-life date is reported.
+const eolSoonWindow = 90 * 24 * time.Hour
+
+// ServerVersion is the flavor and version of a server.
+type ServerVersion struct {
+	Flavor  string
+	Version string
+	// RedisVersion is the Redis version the server reports compatibility
+	// with; it equals Version for Redis itself.
+	RedisVersion string
+}
+
+// String renders the flavor and version, e.g. "valkey 8.0.1".
+func (v ServerVersion) String() string {
+	return v.Flavor + " " + v.Version
+}
+
+// DetectServer identifies the server flavor and version from INFO server.
+func DetectServer(info map[string]string) ServerVersion {
+	v := ServerVersion{Flavor: FlavorRedis, Version: info["redis_version"], RedisVersion: info["redis_version"]}
+	switch {
+	case info["valkey_version"] != "" || info["server_name"] == "valkey":
+		v.Flavor = FlavorValkey
+		if info["valkey_version"] != "" {
+			v.Version = info["valkey_version"]
+		}
+	case info["dragonfly_version"] != "":
+		v.Flavor = FlavorDragonfly
+		v.Version = strings.TrimPrefix(info["dragonfly_version"], "df-v")
+	case strings.Contains(strings.ToLower(info["executable"]), "keydb") ||
+		strings.Contains(strings.ToLower(info["config_file"]), "keydb"):
+		v.Flavor = FlavorKeyDB
+	}
+	return v
+}
+
+// version is a parsed major.minor.patch version.
+type version [3]int
+
+func parseVersion(s string) (version, bool) {
+	var v version
+	parts := strings.SplitN(s, ".", 3)
+	if len(parts) < 2 {
+		return v, false
+	}
+	for i, p := range parts {
+		// Drop suffixes such as "-rc1".
+		if j := strings.IndexFunc(p, func(r rune) bool { return r < '0' || r > '9' }); j >= 0 {
+			p = p[:j]
+		}
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func (v version) less(o version) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+func (v version) series() string {
+	return fmt.Sprintf("%d.%d", v[0], v[1])
+}
+
+func (v version) sameSeries(o version) bool {
+	return v[0] == o[0] && v[1] == o[1]
+}
+
+//go:embed versions.json
+var embeddedVersionData []byte
+
+// VersionData lists release series with their end-of-life dates and the
+// patch versions that fix known CVEs, per flavor.
+type VersionData struct {
+	Updated  string                    `json:"updated"`
+	Products map[string]ProductVersion `json:"products"`
+}
+
+// ProductVersion is the release and CVE data of one flavor.
+type ProductVersion struct {
+	Releases []ReleaseSeries `json:"releases"`
+	CVEs     []CVE           `json:"cves"`
+}
+
+// ReleaseSeries is a major.minor release line; EOL is empty while it is
+// supported.
+type ReleaseSeries struct {
+	Series string `json:"series"`
+	EOL    string `json:"eol,omitempty"`
+}
+
+// CVE is a vulnerability with the first fixed version in each series that
+// received the fix. Series older than the newest fixed series that did not
+// get a fix are vulnerable; Introduced, when set, excludes older versions.
+type CVE struct {
+	ID         string   `json:"id"`
+	Severity   Severity `json:"severity"`
+	Summary    string   `json:"summary"`
+	Fixed      []string `json:"fixed"`
+	Introduced string   `json:"introduced,omitempty"`
+}
+
+// defaultVersionData parses the embedded table once; callers share the
+// result and must not modify it.
+var defaultVersionData = sync.OnceValues(func() (*VersionData, error) {
+	data, err := ParseVersionData(bytes.NewReader(embeddedVersionData))
+	if err != nil {
+		return nil, fmt.Errorf("embedded versions.json: %w", err)
+	}
+	return data, nil
+})
+
+// DefaultVersionData returns the version table compiled into the binary.
+func DefaultVersionData() (*VersionData, error) {
+	return defaultVersionData()
+}
+
+// ParseVersionData reads and validates a version table in the format of
+// the embedded versions.json.
+func ParseVersionData(r io.Reader) (*VersionData, error) {
+	var data VersionData
+	if err := json.NewDecoder(r).Decode(&data); err != nil {
+		return nil, fmt.Errorf("decode version data: %w", err)
+	}
+	for flavor, p := range data.Products {
+		for _, rel := range p.Releases {
+			if _, ok := parseVersion(rel.Series); !ok {
+				return nil, fmt.Errorf("%s: invalid series %q", flavor, rel.Series)
+			}
+			if rel.EOL != "" {
+				if _, err := time.Parse(time.DateOnly, rel.EOL); err != nil {
+					return nil, fmt.Errorf("%s %s: invalid eol date: %w", flavor, rel.Series, err)
+				}
+			}
+		}
+		for _, c := range p.CVEs {
+			if c.ID == "" || len(c.Fixed) == 0 {
+				return nil, fmt.Errorf("%s: CVE entries need an id and fixed versions", flavor)
+			}
+			if SeverityRank(c.Severity) == 0 {
+				return nil, fmt.Errorf("%s %s: invalid severity %q", flavor, c.ID, c.Severity)
+			}
+			for _, f := range c.Fixed {
+				if _, ok := parseVersion(f); !ok {
+					return nil, fmt.Errorf("%s %s: invalid fixed version %q", flavor, c.ID, f)
+				}
+			}
+			if _, ok := parseVersion(c.Introduced); c.Introduced != "" && !ok {
+				return nil, fmt.Errorf("%s %s: invalid introduced version %q", flavor, c.ID, c.Introduced)
+			}
+		}
+	}
+	return &data, nil
+}
+
+// fixedIn returns the version fixing c for v, and whether v is vulnerable.
+// When v's series has no fix, the lowest fix in a newer series is returned.
+func (c CVE) fixedIn(v version) (string, bool) {
+	var newest version
+	var upgrade []version
+	for _, f := range c.Fixed {
+		fv, _ := parseVersion(f)
+		if fv.sameSeries(v) {
+			return f, v.less(fv)
+		}
+		if newest.less(fv) {
+			newest = fv
+		}
+		if v.less(fv) {
+			upgrade = append(upgrade, fv)
+		}
+	}
+	if !v.less(newest) {
+		// A series newer than every fix shipped with the fix.
+		return "", false
+	}
+	if c.Introduced != "" {
+		if iv, _ := parseVersion(c.Introduced); v.less(iv) {
+			return "", false
+		}
+	}
+	sort.Slice(upgrade, func(i, j int) bool { return upgrade[i].less(upgrade[j]) })
+	u := upgrade[0]
+	return fmt.Sprintf("%d.%d.%d", u[0], u[1], u[2]), true
+}
+
+// VersionScanner compares the server version with release end-of-life
+// dates and known CVEs.
+type VersionScanner struct{}
+
+func (s *VersionScanner) Name() string { return "version" }
+
+func (s *VersionScanner) Audit(ctx context.Context, client RedisClient, cfg AuditConfig) ([]Finding, error) {
+	raw, err := client.Info(ctx, "server")
+	if err != nil {
+		return nil, fmt.Errorf("info server: %w", err)
+	}
+	server := DetectServer(ParseInfo(raw))
+	v, ok := parseVersion(server.Version)
+	if !ok {
+		return nil, nil
+	}
+
+	data := cfg.VersionData
+	if data == nil {
+		if data, err = DefaultVersionData(); err != nil {
+			return nil, err
+		}
+	}
+	cfg.inventory.set("version", map[string]any{
+		"flavor":        server.Flavor,
+		"version":       server.Version,
+		"redis_version": server.RedisVersion,
+		"data_updated":  data.Updated,
+	})
+
+	product, ok := data.Products[server.Flavor]
+	if !ok {
+		return nil, nil
+	}
+
+	var findings []Finding
+	if f, ok := s.eol(cfg, server, v, product); ok {
+		findings = append(findings, f)
+	}
+	for _, c := range product.CVEs {
+		fixed, vulnerable := c.fixedIn(v)
+		if !vulnerable {
+			continue
+		}
+		findings = append(findings, Finding{
+			ID:           FindingVersionVulnerable,
+			Severity:     c.Severity,
+			ResourceType: "Redis",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("%s is affected by %s (%s); fixed in %s", server, c.ID, c.Summary, fixed),
+			Metadata: map[string]any{
+				"flavor":   server.Flavor,
+				"version":  server.Version,
+				"cve":      c.ID,
+				"fixed_in": fixed,
+				"summary":  c.Summary,
+			},
+		})
+	}
+	return findings, nil
+}
+
+func (s *VersionScanner) eol(cfg AuditConfig, server ServerVersion, v version, product ProductVersion) (Finding, bool) {
+	var newest version
+	var eol string
+	known := false
+	for _, rel := range product.Releases {
+		rv, _ := parseVersion(rel.Series)
+		if newest.less(rv) {
+			newest = rv
+		}
+		if rv.sameSeries(v) {
+			eol, known = rel.EOL, true
+		}
+	}
+	if !known || eol == "" {
+		return Finding{}, false
+	}
+	date, _ := time.Parse(time.DateOnly, eol)
+	meta := map[string]any{
+		"flavor":         server.Flavor,
+		"version":        server.Version,
+		"series":         v.series(),
+		"eol":            eol,
+		"latest_series":  newest.series(),
+		"recommendation": fmt.Sprintf("upgrade to a supported %s series such as %s", server.Flavor, newest.series()),
+	}
+	switch {
+	case time.Now().After(date):
+		return Finding{
+			ID:           FindingVersionEOL,
+			Severity:     SeverityHigh,
+			ResourceType: "Redis",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("%s reached end of life on %s and no longer receives security fixes", server, eol),
+			Metadata:     meta,
+		}, true
+	case time.Until(date) < eolSoonWindow:
+		return Finding{
+			ID:           FindingVersionEOL,
+			Severity:     SeverityLow,
+			ResourceType: "Redis",
+			ResourceID:   cfg.Addr,
+			Message:      fmt.Sprintf("%s reaches end of life on %s", server, eol),
+			Metadata:     meta,
+		}, true
+	}
+	return Finding{}, false
+}
@@ -0,0 +1,156 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersionScanner_Name(t *testing.T) {
+	s := &VersionScanner{}
+	if s.Name() != "version" {
+		t.Errorf("expected name 'version', got %q", s.Name())
+	}
+}
+
+func TestDetectServer(t *testing.T) {
+	tests := []struct {
+		name    string
+		info    map[string]string
+		flavor  string
+		version string
+	}{
+		{"redis", map[string]string{"redis_version": "7.2.4"}, FlavorRedis, "7.2.4"},
+		{"valkey", map[string]string{"redis_version": "7.2.4", "server_name": "valkey", "valkey_version": "8.0.1"}, FlavorValkey, "8.0.1"},
+		{"dragonfly", map[string]string{"redis_version": "7.4.0", "dragonfly_version": "df-v1.21.2"}, FlavorDragonfly, "1.21.2"},
+		{"keydb", map[string]string{"redis_version": "6.3.4", "executable": "/usr/bin/keydb-server"}, FlavorKeyDB, "6.3.4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectServer(tt.info)
+			if got.Flavor != tt.flavor || got.Version != tt.version {
+				t.Errorf("expected %s %s, got %s", tt.flavor, tt.version, got)
+			}
+		})
+	}
+}
+
+func TestDefaultVersionData(t *testing.T) {
+	data, err := DefaultVersionData()
+	if err != nil {
+		t.Fatalf("embedded version data: %v", err)
+	}
+	if len(data.Products[FlavorRedis].CVEs) == 0 {
+		t.Fatal("expected embedded Redis CVEs")
+	}
+	if again, _ := DefaultVersionData(); again != data {
+		t.Error("expected the embedded table to be parsed once and cached")
+	}
+}
+
+func TestParseVersionData_Invalid(t *testing.T) {
+	for _, raw := range []string{
+		`{`,
+		`{"products":{"redis":{"releases":[{"series":"x"}]}}}`,
+		`{"products":{"redis":{"releases":[{"series":"7.0","eol":"soon"}]}}}`,
+		`{"products":{"redis":{"cves":[{"id":"CVE-1","severity":"high"}]}}}`,
+		`{"products":{"redis":{"cves":[{"id":"CVE-1","severity":"bad","fixed":["7.0.1"]}]}}}`,
+	} {
+		if _, err := ParseVersionData(strings.NewReader(raw)); err == nil {
+			t.Errorf("expected error for %s", raw)
+		}
+	}
+}
+
+func TestCVEFixedIn(t *testing.T) {
+	c := CVE{ID: "CVE-2025-49844", Fixed: []string{"8.2.2", "8.0.4", "7.4.6", "7.2.11", "6.2.20"}}
+	tests := []struct {
+		version    string
+		vulnerable bool
+		fixed      string
+	}{
+		{"7.2.4", true, "7.2.11"},
+		{"7.2.11", false, "7.2.11"},
+		{"7.0.15", true, "7.2.11"},
+		{"5.0.14", true, "6.2.20"},
+		{"8.4.0", false, ""},
+	}
+	for _, tt := range tests {
+		v, _ := parseVersion(tt.version)
+		fixed, vulnerable := c.fixedIn(v)
+		if vulnerable != tt.vulnerable || fixed != tt.fixed {
+			t.Errorf("%s: got %q %v, want %q %v", tt.version, fixed, vulnerable, tt.fixed, tt.vulnerable)
+		}
+	}
+
+	introduced := CVE{Fixed: []string{"7.0.5"}, Introduced: "6.0.0"}
+	v, _ := parseVersion("5.0.14")
+	if _, vulnerable := introduced.fixedIn(v); vulnerable {
+		t.Error("expected versions before introduced to be unaffected")
+	}
+}
+
+func TestVersionScanner(t *testing.T) {
+	past := time.Now().AddDate(-1, 0, 0).Format(time.DateOnly)
+	soon := time.Now().AddDate(0, 1, 0).Format(time.DateOnly)
+	data := &VersionData{Products: map[string]ProductVersion{
+		FlavorRedis: {
+			Releases: []ReleaseSeries{{Series: "5.0", EOL: past}, {Series: "7.2", EOL: soon}, {Series: "7.4"}},
+			CVEs: []CVE{
+				{ID: "CVE-A", Severity: SeverityCritical, Summary: "a", Fixed: []string{"7.4.6", "7.2.11"}},
+				{ID: "CVE-B", Severity: SeverityHigh, Summary: "b", Fixed: []string{"6.2.5", "5.0.13"}},
+			},
+		},
+	}}
+
+	tests := []struct {
+		name    string
+		version string
+		want    map[FindingID]Severity
+		cves    int
+	}{
+		{"eol and unpatched", "5.0.7", map[FindingID]Severity{FindingVersionEOL: SeverityHigh}, 2},
+		{"eol soon", "7.2.11", map[FindingID]Severity{FindingVersionEOL: SeverityLow}, 0},
+		{"supported and patched", "7.4.6", nil, 0},
+		{"supported and unpatched", "7.4.1", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newMockClient()
+			mock.infoResponses["server"] = "redis_version:" + tt.version + "\r\n"
+
+			s := &VersionScanner{}
+			findings, err := s.Audit(context.Background(), mock, AuditConfig{VersionData: data})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := findingsByID(findings)
+			for id, sev := range tt.want {
+				if len(got[id]) != 1 || got[id][0].Severity != sev {
+					t.Errorf("expected %s %s, got %+v", sev, id, got[id])
+				}
+			}
+			if tt.want == nil && len(got[FindingVersionEOL]) != 0 {
+				t.Errorf("expected no EOL finding, got %+v", got[FindingVersionEOL])
+			}
+			if len(got[FindingVersionVulnerable]) != tt.cves {
+				t.Errorf("expected %d CVE findings, got %+v", tt.cves, got[FindingVersionVulnerable])
+			}
+		})
+	}
+}
+
+func TestVersionScanner_UnknownFlavor(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["server"] = "redis_version:6.3.4\r\nexecutable:/opt/keydb/bin/keydb-server\r\n"
+
+	s := &VersionScanner{}
+	findings, err := s.Audit(context.Background(), mock, AuditConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("expected no findings without KeyDB data, got %+v", findings)
+	}
+}
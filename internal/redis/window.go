@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// windowRateCounters names the per-second rates reported for a sample
+// window, keyed by the INFO counter they are computed from.
+var windowRateCounters = map[string]string{
+	"total_commands_processed":   "ops_per_sec",
+	"evicted_keys":               "evictions_per_sec",
+	"expired_keys":               "expirations_per_sec",
+	"total_net_input_bytes":      "net_input_bytes_per_sec",
+	"total_net_output_bytes":     "net_output_bytes_per_sec",
+	"total_connections_received": "connections_per_sec",
+	"rejected_connections":       "rejected_connections_per_sec",
+}
+
+// WindowRates holds the snapshots taken across an in-audit sample window
+// and turns them into per-second rates.
+type WindowRates struct {
+	Samples []Snapshot
+}
+
+// SampleWindow takes a snapshot every interval until window has passed since
+// start. A zero interval samples once, at the end of the window. It fails if
+// the server restarts during the window.
+func SampleWindow(ctx context.Context, client RedisClient, start Snapshot, window, interval time.Duration) (*WindowRates, error) {
+	if interval <= 0 || interval > window {
+		interval = window
+	}
+	w := &WindowRates{Samples: []Snapshot{start}}
+	deadline := start.Time.Add(window)
+	for {
+		wait := min(interval, time.Until(deadline))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		snap, err := TakeSnapshot(ctx, client)
+		if err != nil {
+			return nil, err
+		}
+		prev := w.Samples[len(w.Samples)-1]
+		if NewCounters(snap, &prev).RestartedSinceLastAudit() {
+			return nil, fmt.Errorf("server restarted during the sample window")
+		}
+		w.Samples = append(w.Samples, snap)
+		if !snap.Time.Before(deadline) {
+			return w, nil
+		}
+	}
+}
+
+// Duration returns the time between the first and last snapshot.
+func (w *WindowRates) Duration() time.Duration {
+	if len(w.Samples) < 2 {
+		return 0
+	}
+	return w.Samples[len(w.Samples)-1].Time.Sub(w.Samples[0].Time)
+}
+
+// PerSecond returns the average per-second increase of a counter across the
+// window.
+func (w *WindowRates) PerSecond(name string) float64 {
+	if len(w.Samples) < 2 {
+		return 0
+	}
+	return counterPerSecond(w.Samples[0], w.Samples[len(w.Samples)-1], name)
+}
+
+// PeakPerSecond returns the highest per-second increase of a counter between
+// consecutive snapshots.
+func (w *WindowRates) PeakPerSecond(name string) float64 {
+	var peak float64
+	for i := 1; i < len(w.Samples); i++ {
+		peak = max(peak, counterPerSecond(w.Samples[i-1], w.Samples[i], name))
+	}
+	return peak
+}
+
+// Last returns the final snapshot of the window.
+func (w *WindowRates) Last() Snapshot {
+	return w.Samples[len(w.Samples)-1]
+}
+
+// summary returns the window's rates for the inventory.
+func (w *WindowRates) summary() map[string]any {
+	out := map[string]any{
+		"window_seconds": w.Duration().Seconds(),
+		"samples":        len(w.Samples),
+	}
+	for counter, name := range windowRateCounters {
+		out[name] = w.PerSecond(counter)
+	}
+	return out
+}
+
+func counterPerSecond(from, to Snapshot, name string) float64 {
+	elapsed := to.Time.Sub(from.Time).Seconds()
+	delta := to.Counters[name] - from.Counters[name]
+	if elapsed <= 0 || delta < 0 {
+		return 0
+	}
+	return float64(delta) / elapsed
+}
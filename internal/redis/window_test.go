@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func windowOf(counter string, values ...int64) *WindowRates {
+	start := time.Now()
+	w := &WindowRates{}
+	for i, v := range values {
+		w.Samples = append(w.Samples, Snapshot{
+			Time:     start.Add(time.Duration(i) * 10 * time.Second),
+			Counters: map[string]int64{counter: v},
+		})
+	}
+	return w
+}
+
+func TestWindowRates(t *testing.T) {
+	w := windowOf("evicted_keys", 0, 1000, 1000, 31000)
+	if w.Duration() != 30*time.Second {
+		t.Errorf("expected 30s window, got %v", w.Duration())
+	}
+	if got := w.PerSecond("evicted_keys"); got < 1033 || got > 1034 {
+		t.Errorf("expected ~1033/s, got %.1f", got)
+	}
+	if got := w.PeakPerSecond("evicted_keys"); got != 3000 {
+		t.Errorf("expected peak 3000/s, got %.1f", got)
+	}
+	if got := w.PerSecond("expired_keys"); got != 0 {
+		t.Errorf("expected 0 for missing counter, got %.1f", got)
+	}
+}
+
+func TestSampleWindow(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["server"] = "run_id:abc\r\nuptime_in_seconds:100\r\n"
+	start, err := TakeSnapshot(context.Background(), mock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	w, err := SampleWindow(context.Background(), mock, start, 30*time.Millisecond, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(w.Samples) < 3 {
+		t.Errorf("expected at least 3 samples, got %d", len(w.Samples))
+	}
+	if w.Duration() < 30*time.Millisecond {
+		t.Errorf("expected window of at least 30ms, got %v", w.Duration())
+	}
+}
+
+func TestSampleWindow_Restart(t *testing.T) {
+	mock := newMockClient()
+	mock.infoResponses["server"] = "run_id:def\r\nuptime_in_seconds:1\r\n"
+	start := Snapshot{Time: time.Now(), RunID: "abc", Uptime: 100}
+
+	if _, err := SampleWindow(context.Background(), mock, start, 10*time.Millisecond, 0); err == nil {
+		t.Fatal("expected error when the server restarts during the window")
+	}
+}
+
+func TestSampleWindow_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := SampleWindow(ctx, newMockClient(), Snapshot{Time: time.Now()}, time.Minute, 0); err == nil {
+		t.Fatal("expected error for canceled context")
+	}
+}
@@ -82,7 +82,7 @@ func (r *SARIFReporter) Generate(data Data) error {
 					},
 				},
 			},
-			Props: f.Metadata,
+			Props: sarifProperties(f),
 		})
 	}
 
@@ -111,6 +111,19 @@ func (r *SARIFReporter) Generate(data Data) error {
 	return nil
 }
 
+// sarifProperties returns the finding metadata plus its owner, if any.
+func sarifProperties(f redis.Finding) map[string]any {
+	if f.Owner == nil {
+		return f.Metadata
+	}
+	props := make(map[string]any, len(f.Metadata)+1)
+	for k, v := range f.Metadata {
+		props[k] = v
+	}
+	props["owner"] = f.Owner
+	return props
+}
+
 func sarifLevel(s redis.Severity) string {
 	switch s {
 	case redis.SeverityCritical, redis.SeverityHigh:
@@ -131,5 +144,69 @@ func buildSARIFRules() []sarifRule {
 		{ID: string(redis.FindingEvictionRisk), ShortDescription: sarifMessage{Text: "Eviction risk"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
 		{ID: string(redis.FindingNoPersistence), ShortDescription: sarifMessage{Text: "No persistence configured"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
 		{ID: string(redis.FindingSlowCommand), ShortDescription: sarifMessage{Text: "Slow command"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingSlowlogDisabled), ShortDescription: sarifMessage{Text: "Slowlog disabled"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingSlowlogMisconfigured), ShortDescription: sarifMessage{Text: "Slowlog misses slow commands"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingDuplicateValue), ShortDescription: sarifMessage{Text: "Duplicate large value"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingCompressibleValues), ShortDescription: sarifMessage{Text: "Compressible values"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingUnsafeSerialization), ShortDescription: sarifMessage{Text: "Unsafe serialization format"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingKeyNamingViolation), ShortDescription: sarifMessage{Text: "Key naming rule violation"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingKeyNameBytes), ShortDescription: sarifMessage{Text: "Key name memory per namespace"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingOrphanedNamespace), ShortDescription: sarifMessage{Text: "Namespace without owner"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingNoAuthentication), ShortDescription: sarifMessage{Text: "No authentication"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingProtectedModeOff), ShortDescription: sarifMessage{Text: "Protected mode disabled"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingBindAllInterfaces), ShortDescription: sarifMessage{Text: "Listening on all interfaces"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingDangerousCommand), ShortDescription: sarifMessage{Text: "Dangerous command exposed"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingDebugCommandEnabled), ShortDescription: sarifMessage{Text: "DEBUG command enabled"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingModuleCommandEnabled), ShortDescription: sarifMessage{Text: "MODULE command enabled"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingACLOverprivileged), ShortDescription: sarifMessage{Text: "Overprivileged ACL user"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingACLNoPassword), ShortDescription: sarifMessage{Text: "ACL user without password"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingACLUserNotConnected), ShortDescription: sarifMessage{Text: "ACL user not connected during the audit"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingACLMultiplePasswords), ShortDescription: sarifMessage{Text: "ACL user with multiple passwords"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingACLDangerousAccess), ShortDescription: sarifMessage{Text: "ACL user with dangerous command access"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingReplicaLinkDown), ShortDescription: sarifMessage{Text: "Replica link down"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingReplicaLag), ShortDescription: sarifMessage{Text: "Replica lagging behind primary"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingReplBacklogTooSmall), ShortDescription: sarifMessage{Text: "Replication backlog too small"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingReplicaWritable), ShortDescription: sarifMessage{Text: "Writable replica"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingMinReplicasNotSet), ShortDescription: sarifMessage{Text: "min-replicas-to-write not set"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingPartialSyncFailures), ShortDescription: sarifMessage{Text: "Partial resync failures"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingRDBSaveFailing), ShortDescription: sarifMessage{Text: "RDB background save failing"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingAOFWriteFailing), ShortDescription: sarifMessage{Text: "AOF write failing"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingStaleSnapshot), ShortDescription: sarifMessage{Text: "Stale RDB snapshot"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingAOFRewriteStuck), ShortDescription: sarifMessage{Text: "AOF rewrite stuck"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingAOFFsyncAlways), ShortDescription: sarifMessage{Text: "appendfsync always on a busy instance"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingSlowFork), ShortDescription: sarifMessage{Text: "Slow fork"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingMaxmemoryNotSet), ShortDescription: sarifMessage{Text: "maxmemory not set"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingMaxmemoryOvercommit), ShortDescription: sarifMessage{Text: "maxmemory exceeds safe share of system memory"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingMaxmemoryOversized), ShortDescription: sarifMessage{Text: "maxmemory far above resident memory"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingEvictionPolicyMismatch), ShortDescription: sarifMessage{Text: "Eviction policy does not fit the workload"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingIdleConnections), ShortDescription: sarifMessage{Text: "Idle connections"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingClientBufferMemory), ShortDescription: sarifMessage{Text: "Large client buffers"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingMaxclientsUtilization), ShortDescription: sarifMessage{Text: "maxclients nearly exhausted"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingLatencyMonitorOff), ShortDescription: sarifMessage{Text: "Latency monitor disabled"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingLatencySpike), ShortDescription: sarifMessage{Text: "Latency spike"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingConnectionChurn), ShortDescription: sarifMessage{Text: "Connection churn"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingEvictionStorm), ShortDescription: sarifMessage{Text: "Eviction storm in progress"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingVersionEOL), ShortDescription: sarifMessage{Text: "Server version past end of life"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingVersionVulnerable), ShortDescription: sarifMessage{Text: "Server version missing a security fix"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingSearchIndexMemory), ShortDescription: sarifMessage{Text: "Search index holds a large share of memory"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingSearchDeletedDocs), ShortDescription: sarifMessage{Text: "Search index dominated by deleted documents"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingSearchIndexUnused), ShortDescription: sarifMessage{Text: "Search index never queried"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingTimeSeriesUnbounded), ShortDescription: sarifMessage{Text: "Time series without retention"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingClusterSlotsUncovered), ShortDescription: sarifMessage{Text: "Cluster slots not served"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingClusterSlotMigrating), ShortDescription: sarifMessage{Text: "Cluster slots left migrating or importing"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingClusterCoverageRisk), ShortDescription: sarifMessage{Text: "Full slot coverage required with masters lacking replicas"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingClusterShardImbalance), ShortDescription: sarifMessage{Text: "Cluster shards unevenly loaded"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingClusterHashTagHotspot), ShortDescription: sarifMessage{Text: "Hash tag pins many keys to one slot"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingServerRestarted), ShortDescription: sarifMessage{Text: "Server restarted"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingMemoryOverhead), ShortDescription: sarifMessage{Text: "Memory dominated by non-dataset overhead"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingMemorySwapping), ShortDescription: sarifMessage{Text: "Dataset partially swapped out"}, DefaultConfig: sarifDefaultLevel{Level: "error"}},
+		{ID: string(redis.FindingFragmentationDiagnosis), ShortDescription: sarifMessage{Text: "jemalloc fragmentation diagnosis"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingCommandAntipattern), ShortDescription: sarifMessage{Text: "Command anti-pattern in use"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingHighCommandLatency), ShortDescription: sarifMessage{Text: "High average command latency"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingCommandFailures), ShortDescription: sarifMessage{Text: "High command failure ratio"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingDominantErrors), ShortDescription: sarifMessage{Text: "Dominant error reply types"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
+		{ID: string(redis.FindingLowHitRatio), ShortDescription: sarifMessage{Text: "Low cache hit ratio"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingEvictionChurn), ShortDescription: sarifMessage{Text: "Heavy eviction churn"}, DefaultConfig: sarifDefaultLevel{Level: "warning"}},
+		{ID: string(redis.FindingStaleExpiredKeys), ShortDescription: sarifMessage{Text: "Expired keys not reclaimed"}, DefaultConfig: sarifDefaultLevel{Level: "note"}},
 	}
 }
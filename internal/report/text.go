@@ -6,6 +6,8 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+
+	"github.com/ppiankov/redisspectre/internal/redis"
 )
 
 // Generate writes human-readable terminal output.
@@ -27,12 +29,24 @@ func (r *TextReporter) Generate(data Data) error {
 	w.printf("Found %d issues\n\n", data.Summary.TotalFindings)
 
 	tw2 := &errWriter{w: tw}
-	tw2.printf("SEVERITY\tTYPE\tRESOURCE\tFINDING\tMESSAGE\n")
-	tw2.printf("--------\t----\t--------\t-------\t-------\n")
-
-	for _, f := range data.Findings {
-		tw2.printf("%s\t%s\t%s\t%s\t%s\n",
-			f.Severity, f.ResourceType, f.ResourceID, f.ID, f.Message)
+	if hasOwners(data.Findings) {
+		tw2.printf("SEVERITY\tTYPE\tRESOURCE\tOWNER\tFINDING\tMESSAGE\n")
+		tw2.printf("--------\t----\t--------\t-----\t-------\t-------\n")
+		for _, f := range data.Findings {
+			owner := "-"
+			if f.Owner != nil {
+				owner = f.Owner.Team
+			}
+			tw2.printf("%s\t%s\t%s\t%s\t%s\t%s\n",
+				f.Severity, f.ResourceType, f.ResourceID, owner, f.ID, f.Message)
+		}
+	} else {
+		tw2.printf("SEVERITY\tTYPE\tRESOURCE\tFINDING\tMESSAGE\n")
+		tw2.printf("--------\t----\t--------\t-------\t-------\n")
+		for _, f := range data.Findings {
+			tw2.printf("%s\t%s\t%s\t%s\t%s\n",
+				f.Severity, f.ResourceType, f.ResourceID, f.ID, f.Message)
+		}
 	}
 	if err := tw.Flush(); err != nil {
 		return err
@@ -57,6 +71,21 @@ func writeTextSummary(w *errWriter, data Data) {
 		parts := formatMapSorted(data.Summary.ByResourceType)
 		w.printf("By resource type:   %s\n", strings.Join(parts, ", "))
 	}
+	if len(data.Summary.ByOwner) > 0 {
+		parts := formatMapSorted(data.Summary.ByOwner)
+		w.printf("By owner:           %s\n", strings.Join(parts, ", "))
+	}
+
+	if len(data.Skipped) > 0 {
+		w.printf("\nNot applicable (%d):\n", len(data.Skipped))
+		for _, s := range data.Skipped {
+			name := s.Auditor
+			if s.Check != "" {
+				name += " " + s.Check
+			}
+			w.printf("  - %s: %s\n", name, s.Reason)
+		}
+	}
 
 	if len(data.Errors) > 0 {
 		w.printf("\nWarnings (%d):\n", len(data.Errors))
@@ -66,6 +95,15 @@ func writeTextSummary(w *errWriter, data Data) {
 	}
 }
 
+func hasOwners(findings []redis.Finding) bool {
+	for _, f := range findings {
+		if f.Owner != nil {
+			return true
+		}
+	}
+	return false
+}
+
 type errWriter struct {
 	w   io.Writer
 	err error
@@ -90,3 +90,58 @@ func TestTextReporter_WithErrors(t *testing.T) {
 		t.Errorf("expected error message in output")
 	}
 }
+
+func TestTextReporter_WithOwners(t *testing.T) {
+	var buf bytes.Buffer
+	r := &TextReporter{Writer: &buf}
+
+	data := Data{
+		Findings: []redis.Finding{
+			{ID: redis.FindingIdleKey, Severity: redis.SeverityMedium, ResourceType: "Key", ResourceID: "session:1", Message: "idle", Owner: &redis.Owner{Team: "@identity"}},
+			{ID: redis.FindingBigKey, Severity: redis.SeverityMedium, ResourceType: "Key", ResourceID: "misc:1", Message: "big"},
+		},
+		Summary: analyzer.Summary{
+			TotalFindings: 2,
+			ByOwner:       map[string]int{"@identity": 1},
+		},
+	}
+
+	if err := r.Generate(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "OWNER") {
+		t.Errorf("expected OWNER column in output")
+	}
+	if !strings.Contains(output, "@identity") {
+		t.Errorf("expected owner team in output")
+	}
+	if !strings.Contains(output, "By owner:") {
+		t.Errorf("expected owner breakdown in summary")
+	}
+}
+
+func TestTextReporter_WithSkipped(t *testing.T) {
+	var buf bytes.Buffer
+	r := &TextReporter{Writer: &buf}
+
+	data := Data{
+		Skipped: []redis.SkippedCheck{
+			{Auditor: "security", Reason: "CONFIG GET is not available"},
+			{Auditor: "replication", Check: "REPLICA_WRITABLE", Reason: "CONFIG GET is not available"},
+		},
+	}
+
+	if err := r.Generate(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "Not applicable (2)") {
+		t.Errorf("expected not applicable section in output")
+	}
+	if !strings.Contains(output, "replication REPLICA_WRITABLE: CONFIG GET is not available") {
+		t.Errorf("expected skipped check with reason in output, got %s", output)
+	}
+}
@@ -15,14 +15,16 @@ type Reporter interface {
 
 // Data holds all information needed to generate a report.
 type Data struct {
-	Tool      string           `json:"tool"`
-	Version   string           `json:"version"`
-	Timestamp time.Time        `json:"timestamp"`
-	Target    Target           `json:"target"`
-	Config    ReportConfig     `json:"config"`
-	Findings  []redis.Finding  `json:"findings"`
-	Summary   analyzer.Summary `json:"summary"`
-	Errors    []string         `json:"errors,omitempty"`
+	Tool      string               `json:"tool"`
+	Version   string               `json:"version"`
+	Timestamp time.Time            `json:"timestamp"`
+	Target    Target               `json:"target"`
+	Config    ReportConfig         `json:"config"`
+	Findings  []redis.Finding      `json:"findings"`
+	Summary   analyzer.Summary     `json:"summary"`
+	Errors    []string             `json:"errors,omitempty"`
+	Skipped   []redis.SkippedCheck `json:"skipped,omitempty"`
+	Inventory map[string]any       `json:"inventory,omitempty"`
 }
 
 // Target identifies what was audited.